@@ -0,0 +1,115 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import "math/bits"
+
+/*
+ * bitset is a fixed-size set of small integers (vertex indices), 64 per word.
+ * Set operations work on whole words, so intersecting neighborhoods of dense
+ * graphs costs V / 64 instead of V. All sets of one algorithm must be made
+ * with the same size.
+ *
+ * set := makeBitset(n)
+ * set.add(3)
+ * set.and(other) // set = set ∩ other
+ */
+
+type bitset []uint64
+
+func makeBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+// add puts i into the set
+func (set bitset) add(i int) {
+	set[i/64] |= 1 << (i % 64)
+}
+
+// remove takes i out of the set
+func (set bitset) remove(i int) {
+	set[i/64] &^= 1 << (i % 64)
+}
+
+// has checks if i is in the set
+func (set bitset) has(i int) bool {
+	return set[i/64]&(1<<(i%64)) != 0
+}
+
+// clone returns an independent copy
+func (set bitset) clone() bitset {
+	return append(bitset(nil), set...)
+}
+
+// and leaves only elements also in other
+func (set bitset) and(other bitset) {
+	for w := range set {
+		set[w] &= other[w]
+	}
+}
+
+// or adds all elements of other
+func (set bitset) or(other bitset) {
+	for w := range set {
+		set[w] |= other[w]
+	}
+}
+
+// andNot removes all elements of other
+func (set bitset) andNot(other bitset) {
+	for w := range set {
+		set[w] &^= other[w]
+	}
+}
+
+// count returns number of elements
+func (set bitset) count() int {
+	total := 0
+	for _, word := range set {
+		total += bits.OnesCount64(word)
+	}
+	return total
+}
+
+// isEmpty checks if there are no elements
+func (set bitset) isEmpty() bool {
+	for _, word := range set {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// intersectionCount returns size of set ∩ other without building it
+func (set bitset) intersectionCount(other bitset) int {
+	total := 0
+	for w := range set {
+		total += bits.OnesCount64(set[w] & other[w])
+	}
+	return total
+}
+
+// first returns the smallest element, or -1 for empty set
+func (set bitset) first() int {
+	for w, word := range set {
+		if word != 0 {
+			return w*64 + bits.TrailingZeros64(word)
+		}
+	}
+	return -1
+}
+
+// elements lists elements in increasing order
+func (set bitset) elements() []int {
+	result := make([]int, 0, set.count())
+	for w, word := range set {
+		for word != 0 {
+			result = append(result, w*64+bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+	return result
+}
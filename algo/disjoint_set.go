@@ -0,0 +1,95 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import "github.com/tolstovrob/graph-go/graph"
+
+/*
+ * DisjointSet (union-find) keeps a partition of node keys into disjoint sets.
+ * It is used by Kruskal and Borůvka, but is useful on its own for any
+ * incremental connectivity question. Both path compression and union by rank
+ * are applied, so every operation is practically O(1).
+ *
+ * ds := MakeDisjointSet(1, 2, 3)
+ * ds.Union(1, 2)
+ * ds.Connected(1, 2) // true
+ *
+ * Keys not added beforehand become singleton sets on first use.
+ */
+
+type DisjointSet struct {
+	parent map[graph.TKey]graph.TKey
+	rank   map[graph.TKey]int
+	count  int
+}
+
+func MakeDisjointSet(keys ...graph.TKey) *DisjointSet {
+	ds := &DisjointSet{
+		parent: make(map[graph.TKey]graph.TKey, len(keys)),
+		rank:   make(map[graph.TKey]int, len(keys)),
+	}
+	for _, key := range keys {
+		ds.Add(key)
+	}
+	return ds
+}
+
+// Add puts key into its own set. Does nothing if key is already known
+func (ds *DisjointSet) Add(key graph.TKey) {
+	if _, exists := ds.parent[key]; exists {
+		return
+	}
+	ds.parent[key] = key
+	ds.count++
+}
+
+// Find returns representative of the set containing key
+func (ds *DisjointSet) Find(key graph.TKey) graph.TKey {
+	ds.Add(key)
+
+	root := key
+	for ds.parent[root] != root {
+		root = ds.parent[root]
+	}
+
+	// Path compression: hang every visited key directly on the root
+	for key != root {
+		next := ds.parent[key]
+		ds.parent[key] = root
+		key = next
+	}
+
+	return root
+}
+
+// Union merges sets of a and b. Returns false if they were already in one set
+func (ds *DisjointSet) Union(a, b graph.TKey) bool {
+	rootA, rootB := ds.Find(a), ds.Find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	// Union by rank: hang lower tree under the higher one
+	if ds.rank[rootA] < ds.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	ds.parent[rootB] = rootA
+	if ds.rank[rootA] == ds.rank[rootB] {
+		ds.rank[rootA]++
+	}
+
+	ds.count--
+	return true
+}
+
+// Connected checks if a and b are in the same set
+func (ds *DisjointSet) Connected(a, b graph.TKey) bool {
+	return ds.Find(a) == ds.Find(b)
+}
+
+// Count returns number of disjoint sets
+func (ds *DisjointSet) Count() int {
+	return ds.count
+}
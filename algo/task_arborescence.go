@@ -0,0 +1,239 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find minimum spanning arborescence of directed graph using
+ * Chu–Liu/Edmonds algorithm
+ *
+ * Arborescence - directed tree where every vertex is reachable from the root
+ * by exactly one path. It is the directed counterpart of spanning tree: simply
+ * ignoring directions (as Prim does) gives tree that may be impossible to walk.
+ *
+ * Algorithm Strategy: every vertex takes its cheapest incoming edge. If these
+ * edges form no cycle, we are done. Otherwise every cycle is contracted into a
+ * single vertex, weights of edges entering the cycle are reduced by the weight
+ * of the cycle edge they would replace, and the smaller graph is solved
+ * recursively. Then cycles are expanded back.
+ */
+
+// ArborescenceResult represents the result of minimum arborescence calculation
+type ArborescenceResult struct {
+	Root        graph.TKey    // Root of the arborescence
+	TotalWeight graph.TWeight // Total weight of all edges in arborescence
+	Edges       []*graph.Edge // Edges of arborescence, one entering every vertex except root
+	Message     string        // Status message
+}
+
+// edmondsArc is an arc of (possibly contracted) graph. id refers to the arc of
+// previous level, or to the original edge on the first level
+type edmondsArc struct {
+	u, v   int
+	weight int64
+	id     int
+}
+
+// FindMinArborescence finds minimum-cost arborescence rooted at root
+// Time Complexity: O(V * E)
+func FindMinArborescence(gr *graph.Graph, root graph.TKey) (*ArborescenceResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if !gr.Options.IsDirected {
+		return nil, graph.ThrowGraphNotDirected()
+	}
+	if _, err := gr.GetNodeByKey(root); err != nil {
+		return nil, fmt.Errorf("root node %d does not exist", root)
+	}
+
+	// Arborescence exists iff every vertex is reachable from root
+	if unreachable := findUnreachable(gr, root); len(unreachable) > 0 {
+		return nil, fmt.Errorf("no arborescence rooted at %d: %d node(s) unreachable from root: %s",
+			root, len(unreachable), formatKeyList(unreachable))
+	}
+
+	// Map node keys to indices and edges to arcs
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	edges := make([]*graph.Edge, 0, len(gr.Edges))
+	for _, edge := range gr.Edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].Key < edges[j].Key })
+
+	arcs := make([]edmondsArc, len(edges))
+	for i, edge := range edges {
+		arcs[i] = edmondsArc{u: index[edge.Source], v: index[edge.Destination], weight: int64(edge.Weight), id: i}
+	}
+
+	chosen := chuLiuEdmonds(len(keys), index[root], arcs)
+
+	result := &ArborescenceResult{Root: root, Edges: []*graph.Edge{}}
+	for _, i := range chosen {
+		result.Edges = append(result.Edges, edges[i])
+		result.TotalWeight += edges[i].Weight
+	}
+	sort.Slice(result.Edges, func(i, j int) bool { return result.Edges[i].Key < result.Edges[j].Key })
+	result.Message = fmt.Sprintf("Minimum arborescence rooted at %d has weight %d", root, result.TotalWeight)
+
+	return result, nil
+}
+
+// chuLiuEdmonds returns indices of arcs forming minimum arborescence
+// All vertices must be reachable from root
+func chuLiuEdmonds(n, root int, arcs []edmondsArc) []int {
+	// Step 1: Every vertex except root takes its cheapest incoming arc
+	inArc := make([]int, n)
+	for v := range inArc {
+		inArc[v] = -1
+	}
+	for i, arc := range arcs {
+		if arc.u == arc.v || arc.v == root {
+			continue
+		}
+		if inArc[arc.v] == -1 || arc.weight < arcs[inArc[arc.v]].weight {
+			inArc[arc.v] = i
+		}
+	}
+
+	// Step 2: Look for cycles among chosen arcs by walking them backwards
+	comp := make([]int, n)
+	visitedBy := make([]int, n)
+	inCycle := make([]bool, n)
+	for v := range comp {
+		comp[v], visitedBy[v] = -1, -1
+	}
+
+	cycles := 0
+	for v := 0; v < n; v++ {
+		x := v
+		for x != root && visitedBy[x] == -1 && comp[x] == -1 {
+			visitedBy[x] = v
+			x = arcs[inArc[x]].u
+		}
+
+		// Walk from v came back to itself: the cycle is everything from x around
+		if x != root && visitedBy[x] == v && comp[x] == -1 {
+			for y := x; comp[y] == -1; y = arcs[inArc[y]].u {
+				comp[y] = cycles
+				inCycle[y] = true
+			}
+			cycles++
+		}
+	}
+
+	// No cycles: cheapest incoming arcs already form the arborescence
+	if cycles == 0 {
+		chosen := []int{}
+		for v := 0; v < n; v++ {
+			if v != root {
+				chosen = append(chosen, inArc[v])
+			}
+		}
+		return chosen
+	}
+
+	// Step 3: Contract every cycle into a single vertex
+	contracted := cycles
+	for v := 0; v < n; v++ {
+		if comp[v] == -1 {
+			comp[v] = contracted
+			contracted++
+		}
+	}
+
+	newArcs := []edmondsArc{}
+	for i, arc := range arcs {
+		cu, cv := comp[arc.u], comp[arc.v]
+		if cu == cv {
+			continue
+		}
+		weight := arc.weight
+		if inCycle[arc.v] {
+			// Entering the cycle at arc.v replaces cycle arc entering arc.v
+			weight -= arcs[inArc[arc.v]].weight
+		}
+		newArcs = append(newArcs, edmondsArc{u: cu, v: cv, weight: weight, id: i})
+	}
+
+	// Step 4: Solve contracted graph and expand cycles back
+	chosen := []int{}
+	entry := make([]int, cycles) // Vertex where each cycle is entered
+	for _, j := range chuLiuEdmonds(contracted, comp[root], newArcs) {
+		i := newArcs[j].id
+		chosen = append(chosen, i)
+		if inCycle[arcs[i].v] {
+			entry[comp[arcs[i].v]] = arcs[i].v
+		}
+	}
+
+	// Keep every cycle arc except the one into the entry vertex
+	for v := 0; v < n; v++ {
+		if inCycle[v] && entry[comp[v]] != v {
+			chosen = append(chosen, inArc[v])
+		}
+	}
+
+	return chosen
+}
+
+// findUnreachable returns sorted vertices not reachable from root along edge directions
+func findUnreachable(gr *graph.Graph, root graph.TKey) []graph.TKey {
+	visited := map[graph.TKey]bool{root: true}
+	queue := []graph.TKey{root}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, neighbor := range gr.AdjacencyMap[current] {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	unreachable := []graph.TKey{}
+	for _, key := range getSortedKeys(gr.Nodes) {
+		if !visited[key] {
+			unreachable = append(unreachable, key)
+		}
+	}
+	return unreachable
+}
+
+// FormatArborescenceResult creates a formatted string representation
+func (result *ArborescenceResult) FormatArborescenceResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("MINIMUM SPANNING ARBORESCENCE (Chu–Liu/Edmonds)\n\n")
+	sb.WriteString(fmt.Sprintf("Root: %s\n", formatNodeName(gr, result.Root)))
+	sb.WriteString(fmt.Sprintf("Total weight: %d\n", result.TotalWeight))
+	sb.WriteString(fmt.Sprintf("Number of edges: %d\n\n", len(result.Edges)))
+
+	sb.WriteString("ARBORESCENCE EDGES:\n")
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s %s\n", "Key", "From", "To", "Weight", "Label"))
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s %s\n", "────", "────", "──", "──────", "─────"))
+
+	for _, edge := range result.Edges {
+		sb.WriteString(fmt.Sprintf("%-8d %-8d %-8d %-12d %s\n",
+			edge.Key, edge.Source, edge.Destination, edge.Weight, edge.Label))
+	}
+
+	return sb.String()
+}
@@ -265,9 +265,17 @@ func normalizeCycle(cycle NegativeCycle) NegativeCycle {
 	copy(normalizedVertices, cycle.Vertices[minIndex:])
 	copy(normalizedVertices[len(cycle.Vertices)-minIndex:], cycle.Vertices[:minIndex])
 
+	// Edges[i] leads from Vertices[i] to the next vertex, so rotate them the same way
+	normalizedEdges := cycle.Edges
+	if len(cycle.Edges) == len(cycle.Vertices) {
+		normalizedEdges = make([]graph.TKey, len(cycle.Edges))
+		copy(normalizedEdges, cycle.Edges[minIndex:])
+		copy(normalizedEdges[len(cycle.Edges)-minIndex:], cycle.Edges[:minIndex])
+	}
+
 	return NegativeCycle{
 		Vertices:    normalizedVertices,
-		Edges:       cycle.Edges, // Edge order doesn't affect cycle identity
+		Edges:       normalizedEdges,
 		TotalWeight: cycle.TotalWeight,
 	}
 }
@@ -347,7 +355,12 @@ func (result *NegativeCyclesResult) FormatNegativeCyclesResult(gr *graph.Graph)
 				nextLabel = fmt.Sprintf(" (%s)", nextNode.Label)
 			}
 
+			// Prefer edge keys stored in the cycle: with parallel or undirected
+			// edges looking the edge up by its ends may find the wrong one
 			edge := findEdgeBetween(gr, current, next)
+			if j < len(cycle.Edges) {
+				edge = gr.Edges[cycle.Edges[j]]
+			}
 			var edgeLabel string
 			if edge != nil && edge.Label != "" {
 				edgeLabel = edge.Label
@@ -0,0 +1,363 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Single-source shortest paths with negative weights using Bellman-Ford
+ * and SPFA, and negative cycle search from a virtual super-source
+ *
+ * Bellman-Ford - relax every edge |V| - 1 times, one more successful relaxation
+ * means a negative cycle reachable from the source
+ * SPFA (Shortest Path Faster Algorithm) - relax only edges leaving vertices
+ * whose distance changed, kept in a queue. Same worst case, much faster on average
+ * Super-source - virtual vertex with zero edges to every vertex. One Bellman-Ford
+ * run from it reaches every negative cycle of the graph at once
+ *
+ * Undirected edges are walked both ways, so a negative undirected edge is a
+ * negative cycle by itself (there and back again).
+ */
+
+// BellmanFordResult represents single-source shortest paths with negative weights
+type BellmanFordResult struct {
+	Source           graph.TKey                `json:"source"`
+	Distances        map[graph.TKey]int64      `json:"distances"`         // Distances to reachable vertices only
+	Predecessors     map[graph.TKey]graph.TKey `json:"predecessors"`      // Previous vertex on the shortest path
+	PredecessorEdges map[graph.TKey]graph.TKey `json:"predecessor_edges"` // Key of the last edge of the shortest path
+	HasNegativeCycle bool                      `json:"has_negative_cycle"`
+	NegativeCycle    *NegativeCycle            `json:"negative_cycle,omitempty"` // Cycle reachable from source, if any
+	Algorithm        string                    `json:"algorithm"`
+	Message          string                    `json:"message"`
+}
+
+// BellmanFord finds shortest paths from src, or a negative cycle reachable from it
+// Time Complexity: O(V * E)
+func BellmanFord(gr *graph.Graph, src graph.TKey) (*BellmanFordResult, error) {
+	if err := validateSingleSource(gr, src); err != nil {
+		return nil, err
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	arcs := buildArcLists(gr)
+	dist := map[graph.TKey]int64{src: 0}
+	prev := make(map[graph.TKey]pathStep)
+
+	// Relaxation phase: |V| - 1 rounds, and one more to detect negative cycle
+	relaxed, changed := graph.TKey(0), false
+	for round := 0; round < len(keys); round++ {
+		relaxed, changed = relaxAllArcs(keys, arcs, dist, prev)
+		if !changed {
+			break
+		}
+	}
+
+	var cycle *NegativeCycle
+	if changed {
+		cycle = extractPredecessorCycle(gr, prev, relaxed, len(keys))
+	}
+
+	return buildBellmanFordResult(src, dist, prev, cycle, "Bellman-Ford"), nil
+}
+
+// SPFA finds shortest paths from src using queue-based Bellman-Ford
+// A vertex whose shortest path got |V| edges long proves a negative cycle
+// Time Complexity: O(V * E) worst case, close to O(E) on typical graphs
+func SPFA(gr *graph.Graph, src graph.TKey) (*BellmanFordResult, error) {
+	if err := validateSingleSource(gr, src); err != nil {
+		return nil, err
+	}
+
+	arcs := buildArcLists(gr)
+	dist := map[graph.TKey]int64{src: 0}
+	prev := make(map[graph.TKey]pathStep)
+	pathLength := map[graph.TKey]int{src: 0}
+	inQueue := map[graph.TKey]bool{src: true}
+	queue := []graph.TKey{src}
+
+	var cycle *NegativeCycle
+	for len(queue) > 0 && cycle == nil {
+		u := queue[0]
+		queue = queue[1:]
+		inQueue[u] = false
+
+		for _, arc := range arcs[u] {
+			if current, reached := dist[arc.to]; reached && dist[u]+arc.weight >= current {
+				continue
+			}
+
+			dist[arc.to] = dist[u] + arc.weight
+			prev[arc.to] = pathStep{from: u, edge: arc.key}
+			pathLength[arc.to] = pathLength[u] + 1
+
+			// Simple path has at most |V| - 1 edges, longer one repeats a vertex
+			if pathLength[arc.to] >= len(gr.Nodes) {
+				cycle = extractPredecessorCycle(gr, prev, arc.to, len(gr.Nodes))
+				break
+			}
+
+			if !inQueue[arc.to] {
+				inQueue[arc.to] = true
+				queue = append(queue, arc.to)
+			}
+		}
+	}
+
+	return buildBellmanFordResult(src, dist, prev, cycle, "SPFA"), nil
+}
+
+// FindNegativeCyclesSuperSource finds negative cycles with a single Bellman-Ford run
+// from virtual vertex connected to every vertex. Every cycle left in the
+// predecessor graph afterwards is negative, so all of them are collected
+// Time Complexity: O(V * E), instead of O(V^2 * E) of running from every vertex
+func FindNegativeCyclesSuperSource(gr *graph.Graph) (*NegativeCyclesResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	_, prev, hasCycle := bellmanFordSuperSource(keys, buildArcLists(gr))
+
+	cycles := []NegativeCycle{}
+	if hasCycle {
+		seen := make(map[string]bool)
+		for _, cycle := range findPredecessorCycles(gr, keys, prev) {
+			normalized := normalizeCycle(cycle)
+			if key := generateCycleKey(normalized); !seen[key] {
+				seen[key] = true
+				cycles = append(cycles, normalized)
+			}
+		}
+	}
+
+	return &NegativeCyclesResult{
+		Cycles:            cycles,
+		HasNegativeCycles: len(cycles) > 0,
+		TotalCycles:       len(cycles),
+		Message:           fmt.Sprintf("Found %d negative cycle(s) in a single run", len(cycles)),
+	}, nil
+}
+
+// bellmanFordSuperSource runs Bellman-Ford from virtual vertex with zero arcs to all vertices
+// Returns distances (potentials), predecessors and whether a negative cycle exists
+func bellmanFordSuperSource(keys []graph.TKey, arcs map[graph.TKey][]weightedArc) (map[graph.TKey]int64, map[graph.TKey]pathStep, bool) {
+	dist := make(map[graph.TKey]int64, len(keys))
+	for _, key := range keys {
+		dist[key] = 0
+	}
+	prev := make(map[graph.TKey]pathStep)
+
+	// Virtual vertex makes |V| + 1 vertices: |V| rounds, and one more to detect cycles
+	for round := 0; round <= len(keys); round++ {
+		if _, changed := relaxAllArcs(keys, arcs, dist, prev); !changed {
+			return dist, prev, false
+		}
+	}
+
+	return dist, prev, true
+}
+
+// relaxAllArcs makes one Bellman-Ford round over all arcs of reached vertices
+// Returns the last relaxed vertex and whether anything changed
+func relaxAllArcs(keys []graph.TKey, arcs map[graph.TKey][]weightedArc, dist map[graph.TKey]int64, prev map[graph.TKey]pathStep) (graph.TKey, bool) {
+	var relaxed graph.TKey
+	changed := false
+
+	for _, u := range keys {
+		du, reached := dist[u]
+		if !reached {
+			continue
+		}
+		for _, arc := range arcs[u] {
+			if current, ok := dist[arc.to]; !ok || du+arc.weight < current {
+				dist[arc.to] = du + arc.weight
+				prev[arc.to] = pathStep{from: u, edge: arc.key}
+				relaxed, changed = arc.to, true
+			}
+		}
+	}
+
+	return relaxed, changed
+}
+
+// extractPredecessorCycle walks predecessors back from start. After n steps the
+// walk is surely inside a cycle, which is then collected
+func extractPredecessorCycle(gr *graph.Graph, prev map[graph.TKey]pathStep, start graph.TKey, n int) *NegativeCycle {
+	x := start
+	for i := 0; i < n; i++ {
+		step, ok := prev[x]
+		if !ok {
+			return nil
+		}
+		x = step.from
+	}
+
+	cycle := collectPredecessorCycle(gr, prev, x)
+	return &cycle
+}
+
+// findPredecessorCycles finds every cycle of predecessor graph. Each vertex has
+// at most one predecessor, so a walk back either ends or runs into a cycle
+func findPredecessorCycles(gr *graph.Graph, keys []graph.TKey, prev map[graph.TKey]pathStep) []NegativeCycle {
+	const (
+		unvisited = iota
+		inWalk
+		done
+	)
+	state := make(map[graph.TKey]int)
+	cycles := []NegativeCycle{}
+
+	for _, start := range keys {
+		walk := []graph.TKey{}
+		x := start
+		for state[x] == unvisited {
+			state[x] = inWalk
+			walk = append(walk, x)
+			step, ok := prev[x]
+			if !ok {
+				break
+			}
+			x = step.from
+		}
+
+		// Walk came back to a vertex of itself: that is a new cycle
+		if state[x] == inWalk {
+			if _, ok := prev[x]; ok {
+				cycles = append(cycles, collectPredecessorCycle(gr, prev, x))
+			}
+		}
+
+		for _, vertex := range walk {
+			state[vertex] = done
+		}
+	}
+
+	return cycles
+}
+
+// collectPredecessorCycle collects cycle of predecessor graph going through x
+// Vertices are ordered along edges, Edges[i] leads from Vertices[i] to the next one
+func collectPredecessorCycle(gr *graph.Graph, prev map[graph.TKey]pathStep, x graph.TKey) NegativeCycle {
+	vertices := []graph.TKey{}
+	edges := []graph.TKey{}
+	totalWeight := graph.TWeight(0)
+
+	for y := x; ; {
+		step := prev[y]
+		vertices = append(vertices, y)
+		edges = append(edges, step.edge)
+		totalWeight += gr.Edges[step.edge].Weight
+		y = step.from
+		if y == x {
+			break
+		}
+	}
+
+	// Predecessors were walked against edge direction. After reversing, edge
+	// into the last vertex has to move to the front to match vertex order
+	slices.Reverse(vertices)
+	slices.Reverse(edges)
+	edges = append(edges[1:], edges[0])
+
+	return NegativeCycle{
+		Vertices:    vertices,
+		Edges:       edges,
+		TotalWeight: totalWeight,
+	}
+}
+
+// validateSingleSource checks graph and source before single-source search
+func validateSingleSource(gr *graph.Graph, src graph.TKey) error {
+	if gr.Nodes == nil {
+		return graph.ThrowNodesListIsNil()
+	}
+	if _, err := gr.GetNodeByKey(src); err != nil {
+		return fmt.Errorf("source node %d does not exist", src)
+	}
+	return nil
+}
+
+// buildBellmanFordResult packs distances and predecessors into result
+func buildBellmanFordResult(src graph.TKey, dist map[graph.TKey]int64, prev map[graph.TKey]pathStep, cycle *NegativeCycle, algorithm string) *BellmanFordResult {
+	result := &BellmanFordResult{
+		Source:           src,
+		Distances:        dist,
+		Predecessors:     make(map[graph.TKey]graph.TKey, len(prev)),
+		PredecessorEdges: make(map[graph.TKey]graph.TKey, len(prev)),
+		Algorithm:        algorithm,
+	}
+	for vertex, step := range prev {
+		result.Predecessors[vertex] = step.from
+		result.PredecessorEdges[vertex] = step.edge
+	}
+
+	if cycle != nil {
+		normalized := normalizeCycle(*cycle)
+		result.HasNegativeCycle = true
+		result.NegativeCycle = &normalized
+		result.Message = fmt.Sprintf("Negative cycle of weight %d is reachable from %d", normalized.TotalWeight, src)
+	} else {
+		result.Message = fmt.Sprintf("Found shortest paths from %d to %d vertices", src, len(dist))
+	}
+
+	return result
+}
+
+// GetPath reconstructs the shortest path from source to target
+// Returns nil if target is unreachable or a negative cycle makes paths undefined
+func (result *BellmanFordResult) GetPath(target graph.TKey) []graph.TKey {
+	if _, reached := result.Distances[target]; !reached || result.HasNegativeCycle {
+		return nil
+	}
+
+	path := []graph.TKey{target}
+	for current := target; current != result.Source; {
+		current = result.Predecessors[current]
+		path = append(path, current)
+	}
+	slices.Reverse(path)
+
+	return path
+}
+
+// FormatBellmanFordResult creates a formatted string representation
+func (result *BellmanFordResult) FormatBellmanFordResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("SINGLE-SOURCE SHORTEST PATHS\n\n")
+	sb.WriteString(fmt.Sprintf("Algorithm: %s\n", result.Algorithm))
+	sb.WriteString(fmt.Sprintf("Source: %s\n", formatNodeName(gr, result.Source)))
+	sb.WriteString(fmt.Sprintf("Reachable vertices: %d/%d\n", len(result.Distances), len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Negative cycle reachable: %v\n\n", result.HasNegativeCycle))
+
+	if result.HasNegativeCycle {
+		cycle := result.NegativeCycle
+		sb.WriteString("NEGATIVE CYCLE (shortest paths are undefined):\n")
+		sb.WriteString(strings.Repeat("─", 50) + "\n")
+		sb.WriteString(formatTrail(append(slices.Clone(cycle.Vertices), cycle.Vertices[0])) + "\n")
+		sb.WriteString(fmt.Sprintf("Edges: %s\n", formatKeyList(cycle.Edges)))
+		sb.WriteString(fmt.Sprintf("Total weight: %d\n", cycle.TotalWeight))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%-16s %-12s %s\n", "Vertex", "Distance", "Path"))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for _, vertex := range getSortedKeys(gr.Nodes) {
+		if _, reached := result.Distances[vertex]; !reached {
+			sb.WriteString(fmt.Sprintf("%-16s %-12s %s\n", formatNodeName(gr, vertex), "inf", "-"))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%-16s %-12d %s\n",
+			formatNodeName(gr, vertex), result.Distances[vertex], formatTrail(result.GetPath(vertex))))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,382 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find articulation points, bridges, 2-edge-connected and biconnected
+ * components using Tarjan's low-link technique
+ *
+ * Articulation point - vertex whose removal increases the number of components
+ * Bridge - edge whose removal increases the number of components
+ * Biconnected component (block) - maximal subgraph without articulation points
+ * Block-cut tree - tree with a node per block and per articulation point
+ *
+ * Everything is computed in a single DFS, so the whole analysis is O(V + E).
+ * Directed graphs are analyzed by their underlying undirected graph. Parallel
+ * edges are told apart by edge key, so a doubled edge is never a bridge.
+ */
+
+// BiconnectedComponent represents a single block of the graph
+type BiconnectedComponent struct {
+	Vertices []graph.TKey // Vertices of the block
+	Edges    []graph.TKey // Keys of edges of the block
+}
+
+// BiconnectivityResult represents the result of low-link analysis
+type BiconnectivityResult struct {
+	ArticulationPoints         []graph.TKey           // Cut vertices, sorted by key
+	Bridges                    []graph.TKey           // Keys of bridge edges, sorted
+	TwoEdgeConnectedComponents [][]graph.TKey         // Components left after removing all bridges
+	BiconnectedComponents      []BiconnectedComponent // Blocks of the graph
+	BlockCutTree               *graph.Graph           // Block-cut tree (forest for disconnected graphs)
+	Message                    string                 // Status message
+}
+
+// incidentEdge is a single entry of incidence list: neighbor and connecting edge
+type incidentEdge struct {
+	to  graph.TKey
+	key graph.TKey
+}
+
+// lowLinkState keeps everything Tarjan's DFS needs between recursive calls
+type lowLinkState struct {
+	incidence    map[graph.TKey][]incidentEdge
+	disc         map[graph.TKey]int
+	low          map[graph.TKey]int
+	timer        int
+	edgeStack    []graph.TKey
+	edgeEnds     map[graph.TKey][2]graph.TKey
+	isArticulate map[graph.TKey]bool
+	bridges      []graph.TKey
+	blocks       []BiconnectedComponent
+}
+
+// FindBiconnectivity finds articulation points, bridges and both kinds of components
+// Time Complexity: O(V + E)
+func FindBiconnectivity(gr *graph.Graph) (*BiconnectivityResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	state := &lowLinkState{
+		incidence:    buildUndirectedIncidence(gr),
+		disc:         make(map[graph.TKey]int),
+		low:          make(map[graph.TKey]int),
+		edgeEnds:     make(map[graph.TKey][2]graph.TKey),
+		isArticulate: make(map[graph.TKey]bool),
+	}
+	for key, edge := range gr.Edges {
+		state.edgeEnds[key] = [2]graph.TKey{edge.Source, edge.Destination}
+	}
+
+	// Run DFS from every unvisited vertex, so disconnected graphs are covered too
+	for _, vertex := range getSortedKeys(gr.Nodes) {
+		if _, visited := state.disc[vertex]; visited {
+			continue
+		}
+
+		state.lowLinkDFS(vertex, 0, false)
+
+		// Vertex without (non-loop) edges forms a block on its own
+		if len(state.edgeStack) == 0 && !hasProperNeighbor(state.incidence[vertex], vertex) {
+			state.blocks = append(state.blocks, BiconnectedComponent{
+				Vertices: []graph.TKey{vertex},
+				Edges:    []graph.TKey{},
+			})
+		}
+	}
+
+	articulationPoints := []graph.TKey{}
+	for vertex := range state.isArticulate {
+		articulationPoints = append(articulationPoints, vertex)
+	}
+	sort.Slice(articulationPoints, func(i, j int) bool { return articulationPoints[i] < articulationPoints[j] })
+	sort.Slice(state.bridges, func(i, j int) bool { return state.bridges[i] < state.bridges[j] })
+
+	bridges := state.bridges
+	if bridges == nil {
+		bridges = []graph.TKey{}
+	}
+
+	return &BiconnectivityResult{
+		ArticulationPoints:         articulationPoints,
+		Bridges:                    bridges,
+		TwoEdgeConnectedComponents: findTwoEdgeConnectedComponents(gr, state.incidence, bridges),
+		BiconnectedComponents:      state.blocks,
+		BlockCutTree:               buildBlockCutTree(gr, state.blocks, state.isArticulate),
+		Message: fmt.Sprintf("Found %d articulation point(s), %d bridge(s), %d block(s)",
+			len(articulationPoints), len(bridges), len(state.blocks)),
+	}, nil
+}
+
+// lowLinkDFS is the core of Tarjan's algorithm
+// low[u] is the smallest discovery time reachable from u's subtree using at most one back edge
+func (state *lowLinkState) lowLinkDFS(u, parentEdge graph.TKey, hasParent bool) {
+	state.timer++
+	state.disc[u] = state.timer
+	state.low[u] = state.timer
+	children := 0
+
+	for _, inc := range state.incidence[u] {
+		// Skip the very edge we came by. Comparing keys instead of vertices lets
+		// parallel edges act as back edges, which is exactly what multigraphs need
+		if hasParent && inc.key == parentEdge {
+			continue
+		}
+
+		v := inc.to
+		if _, visited := state.disc[v]; !visited {
+			children++
+			state.edgeStack = append(state.edgeStack, inc.key)
+			state.lowLinkDFS(v, inc.key, true)
+
+			state.low[u] = min(state.low[u], state.low[v])
+
+			// Nothing in v's subtree climbs above u: edge u-v is a bridge
+			if state.low[v] > state.disc[u] {
+				state.bridges = append(state.bridges, inc.key)
+			}
+
+			// Nothing in v's subtree climbs above u without u: block is complete
+			if state.low[v] >= state.disc[u] {
+				if hasParent {
+					state.isArticulate[u] = true
+				}
+				state.popBlock(inc.key)
+			}
+		} else if state.disc[v] < state.disc[u] {
+			// Back edge to an ancestor
+			state.edgeStack = append(state.edgeStack, inc.key)
+			state.low[u] = min(state.low[u], state.disc[v])
+		}
+	}
+
+	// DFS root is articulation point only if it has several DFS children
+	if !hasParent && children > 1 {
+		state.isArticulate[u] = true
+	}
+}
+
+// popBlock pops edges from the stack down to (and including) the given tree edge
+func (state *lowLinkState) popBlock(treeEdge graph.TKey) {
+	vertexSet := make(map[graph.TKey]bool)
+	edges := []graph.TKey{}
+
+	for len(state.edgeStack) > 0 {
+		key := state.edgeStack[len(state.edgeStack)-1]
+		state.edgeStack = state.edgeStack[:len(state.edgeStack)-1]
+
+		edges = append(edges, key)
+		ends := state.edgeEnds[key]
+		vertexSet[ends[0]] = true
+		vertexSet[ends[1]] = true
+
+		if key == treeEdge {
+			break
+		}
+	}
+
+	vertices := make([]graph.TKey, 0, len(vertexSet))
+	for vertex := range vertexSet {
+		vertices = append(vertices, vertex)
+	}
+	sort.Slice(vertices, func(i, j int) bool { return vertices[i] < vertices[j] })
+	sort.Slice(edges, func(i, j int) bool { return edges[i] < edges[j] })
+
+	state.blocks = append(state.blocks, BiconnectedComponent{Vertices: vertices, Edges: edges})
+}
+
+// findTwoEdgeConnectedComponents removes bridges and collects what stays connected
+func findTwoEdgeConnectedComponents(gr *graph.Graph, incidence map[graph.TKey][]incidentEdge, bridges []graph.TKey) [][]graph.TKey {
+	isBridge := make(map[graph.TKey]bool)
+	for _, key := range bridges {
+		isBridge[key] = true
+	}
+
+	visited := make(map[graph.TKey]bool)
+	components := [][]graph.TKey{}
+
+	for _, start := range getSortedKeys(gr.Nodes) {
+		if visited[start] {
+			continue
+		}
+
+		component := []graph.TKey{}
+		queue := []graph.TKey{start}
+		visited[start] = true
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+
+			for _, inc := range incidence[current] {
+				if !isBridge[inc.key] && !visited[inc.to] {
+					visited[inc.to] = true
+					queue = append(queue, inc.to)
+				}
+			}
+		}
+
+		sort.Slice(component, func(i, j int) bool { return component[i] < component[j] })
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// buildBlockCutTree creates undirected graph where every block and every articulation
+// point is a node. Articulation points keep their keys and labels, blocks get
+// fresh keys after the largest node key and "Block N" labels
+func buildBlockCutTree(gr *graph.Graph, blocks []BiconnectedComponent, isArticulate map[graph.TKey]bool) *graph.Graph {
+	tree := graph.MakeGraph(graph.WithGraphDirected(false))
+
+	for vertex := range isArticulate {
+		tree.Nodes[vertex] = graph.MakeNode(vertex, graph.WithNodeLabel(gr.Nodes[vertex].Label))
+	}
+
+	nextKey := graph.TKey(0)
+	for key := range gr.Nodes {
+		nextKey = max(nextKey, key)
+	}
+
+	edgeKey := graph.TKey(1)
+	for i, block := range blocks {
+		nextKey++
+		blockKey := nextKey
+		tree.Nodes[blockKey] = graph.MakeNode(blockKey, graph.WithNodeLabel(fmt.Sprintf("Block %d", i+1)))
+
+		for _, vertex := range block.Vertices {
+			if isArticulate[vertex] {
+				tree.Edges[edgeKey] = graph.MakeEdge(edgeKey, blockKey, vertex)
+				edgeKey++
+			}
+		}
+	}
+
+	tree.RebuildAdjacencyMap()
+	return tree
+}
+
+// Helper functions for graph operations
+
+// buildUndirectedIncidence builds incidence lists of underlying undirected graph
+// Edges are processed in key order, so every traversal over it is deterministic
+func buildUndirectedIncidence(gr *graph.Graph) map[graph.TKey][]incidentEdge {
+	incidence := make(map[graph.TKey][]incidentEdge)
+
+	edgeKeys := make([]graph.TKey, 0, len(gr.Edges))
+	for key := range gr.Edges {
+		edgeKeys = append(edgeKeys, key)
+	}
+	sort.Slice(edgeKeys, func(i, j int) bool { return edgeKeys[i] < edgeKeys[j] })
+
+	for _, key := range edgeKeys {
+		edge := gr.Edges[key]
+		incidence[edge.Source] = append(incidence[edge.Source], incidentEdge{to: edge.Destination, key: key})
+		if edge.Source != edge.Destination {
+			incidence[edge.Destination] = append(incidence[edge.Destination], incidentEdge{to: edge.Source, key: key})
+		}
+	}
+
+	return incidence
+}
+
+// hasProperNeighbor checks if vertex has at least one edge which is not a loop
+func hasProperNeighbor(incidence []incidentEdge, vertex graph.TKey) bool {
+	for _, inc := range incidence {
+		if inc.to != vertex {
+			return true
+		}
+	}
+	return false
+}
+
+// formatNodeName returns "key (label)" or just "key" for unlabeled nodes
+func formatNodeName(gr *graph.Graph, key graph.TKey) string {
+	if node, _ := gr.GetNodeByKey(key); node != nil && node.Label != "" {
+		return fmt.Sprintf("%d (%s)", key, node.Label)
+	}
+	return fmt.Sprintf("%d", key)
+}
+
+// formatKeyList joins keys into "1, 2, 3"
+func formatKeyList(keys []graph.TKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%d", key)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatBiconnectivityResult creates a formatted string representation
+func (result *BiconnectivityResult) FormatBiconnectivityResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("BRIDGES, ARTICULATION POINTS AND BLOCKS\n\n")
+	sb.WriteString("Algorithm: Tarjan's low-link DFS\n")
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Total edges: %d\n", len(gr.Edges)))
+	if gr.Options.IsDirected {
+		sb.WriteString("Note: directed graph is analyzed as undirected\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(fmt.Sprintf("ARTICULATION POINTS (%d):\n", len(result.ArticulationPoints)))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	if len(result.ArticulationPoints) == 0 {
+		sb.WriteString("None - no single vertex disconnects the graph\n")
+	}
+	for i, vertex := range result.ArticulationPoints {
+		sb.WriteString(fmt.Sprintf("%d. Vertex %s\n", i+1, formatNodeName(gr, vertex)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nBRIDGES (%d):\n", len(result.Bridges)))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	if len(result.Bridges) == 0 {
+		sb.WriteString("None - no single edge disconnects the graph\n")
+	}
+	for i, key := range result.Bridges {
+		edge := gr.Edges[key]
+		sb.WriteString(fmt.Sprintf("%d. Edge %d: %s - %s", i+1, key,
+			formatNodeName(gr, edge.Source), formatNodeName(gr, edge.Destination)))
+		if edge.Label != "" {
+			sb.WriteString(fmt.Sprintf(" [%s]", edge.Label))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\n2-EDGE-CONNECTED COMPONENTS (%d):\n", len(result.TwoEdgeConnectedComponents)))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for i, component := range result.TwoEdgeConnectedComponents {
+		sb.WriteString(fmt.Sprintf("%d. {%s}\n", i+1, formatKeyList(component)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nBICONNECTED COMPONENTS (%d):\n", len(result.BiconnectedComponents)))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for i, block := range result.BiconnectedComponents {
+		sb.WriteString(fmt.Sprintf("Block %d: vertices {%s}", i+1, formatKeyList(block.Vertices)))
+		if len(block.Edges) > 0 {
+			sb.WriteString(fmt.Sprintf(", edges {%s}", formatKeyList(block.Edges)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\nBLOCK-CUT TREE:\n")
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	sb.WriteString(fmt.Sprintf("%d nodes (%d blocks, %d cut vertices), %d edges\n",
+		len(result.BlockCutTree.Nodes), len(result.BiconnectedComponents),
+		len(result.ArticulationPoints), len(result.BlockCutTree.Edges)))
+
+	return sb.String()
+}
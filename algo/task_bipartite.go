@@ -0,0 +1,370 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Check if graph is bipartite and find maximum matching in bipartite graph
+ *
+ * Bipartite graph - vertices split into two sides, every edge joins different
+ * sides. BFS colors vertices layer by layer; an edge inside one layer parity
+ * closes an odd cycle through the lowest common ancestor of its ends, and no
+ * graph with an odd cycle can be two-colored. Time Complexity: O(V + E)
+ *
+ * Hopcroft-Karp Algorithm - matching grows along augmenting paths (free left
+ * vertex → free right vertex, alternating unmatched and matched edges). Each
+ * phase finds a maximal set of vertex-disjoint shortest augmenting paths with
+ * one BFS and one DFS. Only O(sqrt(V)) phases are needed, so O(E * sqrt(V)).
+ *
+ * König's theorem - in bipartite graph minimum vertex cover has the size of
+ * maximum matching. Let Z be vertices reachable from free left vertices by
+ * alternating paths. Then (Left \ Z) ∪ (Right ∩ Z) is such a cover.
+ *
+ * Directed graphs are checked by their underlying undirected graph.
+ */
+
+// BipartiteResult represents two-coloring of the graph or a proof that there is none
+type BipartiteResult struct {
+	IsBipartite   bool         // True if vertices can be split into two sides
+	Left          []graph.TKey // Vertices of color 0
+	Right         []graph.TKey // Vertices of color 1
+	OddCycle      []graph.TKey // Vertices of an odd cycle, the first one is not repeated
+	OddCycleEdges []graph.TKey // Keys of edges of the odd cycle, in order
+	Message       string       // Status message
+}
+
+// MatchingResult represents maximum matching with minimum vertex cover
+type MatchingResult struct {
+	Size        int                       // Number of matched edges
+	Edges       []graph.TKey              // Keys of matched edges, sorted
+	Mate        map[graph.TKey]graph.TKey // Partner of every matched vertex
+	Left        []graph.TKey              // Left side of bipartition
+	Right       []graph.TKey              // Right side of bipartition
+	VertexCover []graph.TKey              // Minimum vertex cover by König's theorem
+	Algorithm   string                    // Algorithm used
+	Message     string                    // Status message
+}
+
+// IsBipartite two-colors the graph by BFS, or finds an odd cycle
+func IsBipartite(gr *graph.Graph) (*BipartiteResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	incidence := buildUndirectedIncidence(gr)
+	color := make(map[graph.TKey]int, len(gr.Nodes))
+	depth := make(map[graph.TKey]int, len(gr.Nodes))
+	parent := make(map[graph.TKey]incidentEdge, len(gr.Nodes)) // Vertex and edge it was found from
+
+	for _, start := range getSortedKeys(gr.Nodes) {
+		if _, seen := color[start]; seen {
+			continue
+		}
+		color[start] = 0
+		depth[start] = 0
+
+		queue := []graph.TKey{start}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			for _, next := range incidence[current] {
+				if _, seen := color[next.to]; !seen {
+					color[next.to] = 1 - color[current]
+					depth[next.to] = depth[current] + 1
+					parent[next.to] = incidentEdge{to: current, key: next.key}
+					queue = append(queue, next.to)
+					continue
+				}
+
+				if color[next.to] == color[current] {
+					cycle, edges := traceOddCycle(parent, depth, current, next)
+					return &BipartiteResult{
+						IsBipartite:   false,
+						OddCycle:      cycle,
+						OddCycleEdges: edges,
+						Message:       fmt.Sprintf("Graph is not bipartite, found odd cycle of length %d", len(edges)),
+					}, nil
+				}
+			}
+		}
+	}
+
+	result := &BipartiteResult{IsBipartite: true, Left: []graph.TKey{}, Right: []graph.TKey{}}
+	for _, key := range getSortedKeys(gr.Nodes) {
+		if color[key] == 0 {
+			result.Left = append(result.Left, key)
+		} else {
+			result.Right = append(result.Right, key)
+		}
+	}
+	result.Message = fmt.Sprintf("Graph is bipartite: %d and %d vertices", len(result.Left), len(result.Right))
+
+	return result, nil
+}
+
+// traceOddCycle closes BFS tree paths from both ends of conflicting edge at their common ancestor
+func traceOddCycle(parent map[graph.TKey]incidentEdge, depth map[graph.TKey]int, u graph.TKey, conflict incidentEdge) ([]graph.TKey, []graph.TKey) {
+	v := conflict.to
+	if u == v {
+		return []graph.TKey{u}, []graph.TKey{conflict.key} // Loop is a cycle of length 1
+	}
+
+	// Climb from both ends until they meet
+	upU, upV := []incidentEdge{}, []incidentEdge{}
+	a, b := u, v
+	for a != b {
+		if depth[a] >= depth[b] {
+			upU = append(upU, incidentEdge{to: a, key: parent[a].key})
+			a = parent[a].to
+		} else {
+			upV = append(upV, incidentEdge{to: b, key: parent[b].key})
+			b = parent[b].to
+		}
+	}
+
+	// Cycle goes ancestor → ... → u → v → ... → ancestor
+	vertices := []graph.TKey{a}
+	edges := []graph.TKey{}
+	for i := len(upU) - 1; i >= 0; i-- {
+		edges = append(edges, upU[i].key)
+		vertices = append(vertices, upU[i].to)
+	}
+	edges = append(edges, conflict.key)
+	for _, step := range upV {
+		vertices = append(vertices, step.to)
+		edges = append(edges, step.key)
+	}
+
+	return vertices, edges
+}
+
+// FindBipartiteMatching finds maximum matching of bipartite graph using Hopcroft-Karp algorithm
+func FindBipartiteMatching(gr *graph.Graph) (*MatchingResult, error) {
+	bipartite, err := IsBipartite(gr)
+	if err != nil {
+		return nil, err
+	}
+	if !bipartite.IsBipartite {
+		return nil, fmt.Errorf("graph is not bipartite, odd cycle: %s", formatTrail(bipartite.OddCycle))
+	}
+
+	left, right := bipartite.Left, bipartite.Right
+	rightIndex := make(map[graph.TKey]int, len(right))
+	for i, key := range right {
+		rightIndex[key] = i
+	}
+	leftIndex := make(map[graph.TKey]int, len(left))
+	for i, key := range left {
+		leftIndex[key] = i
+	}
+
+	// Every edge goes from left side to right side
+	adj := make([][]incidentEdge, len(left)) // to is index of right vertex
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		u, v := edge.Source, edge.Destination
+		if _, ok := leftIndex[u]; !ok {
+			u, v = v, u
+		}
+		adj[leftIndex[u]] = append(adj[leftIndex[u]], incidentEdge{to: graph.TKey(rightIndex[v]), key: key})
+	}
+
+	const free = -1
+	matchLeft := make([]int, len(left))   // Right vertex matched to left one
+	matchRight := make([]int, len(right)) // Left vertex matched to right one
+	matchEdge := make([]graph.TKey, len(left))
+	for i := range matchLeft {
+		matchLeft[i] = free
+	}
+	for i := range matchRight {
+		matchRight[i] = free
+	}
+
+	dist := make([]int, len(left))
+
+	// bfs layers left vertices by alternating distance from free ones
+	bfs := func() bool {
+		queue := []int{}
+		for u := range left {
+			if matchLeft[u] == free {
+				dist[u] = 0
+				queue = append(queue, u)
+			} else {
+				dist[u] = -1
+			}
+		}
+
+		found := false
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, arc := range adj[u] {
+				w := matchRight[arc.to]
+				if w == free {
+					found = true
+				} else if dist[w] < 0 {
+					dist[w] = dist[u] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+
+	// dfs augments along a shortest path from u, following BFS layers
+	next := make([]int, len(left))
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		for ; next[u] < len(adj[u]); next[u]++ {
+			arc := adj[u][next[u]]
+			w := matchRight[arc.to]
+			if w == free || (dist[w] == dist[u]+1 && dfs(w)) {
+				matchLeft[u] = int(arc.to)
+				matchRight[arc.to] = u
+				matchEdge[u] = arc.key
+				return true
+			}
+		}
+		dist[u] = -1 // Dead end for this phase
+		return false
+	}
+
+	size := 0
+	for bfs() {
+		clear(next)
+		for u := range left {
+			if matchLeft[u] == free && dfs(u) {
+				size++
+			}
+		}
+	}
+
+	result := &MatchingResult{
+		Size:      size,
+		Edges:     []graph.TKey{},
+		Mate:      make(map[graph.TKey]graph.TKey, 2*size),
+		Left:      left,
+		Right:     right,
+		Algorithm: "Hopcroft-Karp",
+	}
+	for u, v := range matchLeft {
+		if v != free {
+			result.Edges = append(result.Edges, matchEdge[u])
+			result.Mate[left[u]] = right[v]
+			result.Mate[right[v]] = left[u]
+		}
+	}
+	slices.Sort(result.Edges)
+
+	// König: alternating search from free left vertices
+	visitedLeft := make([]bool, len(left))
+	visitedRight := make([]bool, len(right))
+	queue := []int{}
+	for u := range left {
+		if matchLeft[u] == free {
+			visitedLeft[u] = true
+			queue = append(queue, u)
+		}
+	}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, arc := range adj[u] {
+			if int(arc.to) == matchLeft[u] || visitedRight[arc.to] {
+				continue
+			}
+			visitedRight[arc.to] = true
+			if w := matchRight[arc.to]; w != free && !visitedLeft[w] {
+				visitedLeft[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	result.VertexCover = []graph.TKey{}
+	for u, key := range left {
+		if !visitedLeft[u] {
+			result.VertexCover = append(result.VertexCover, key)
+		}
+	}
+	for v, key := range right {
+		if visitedRight[v] {
+			result.VertexCover = append(result.VertexCover, key)
+		}
+	}
+	slices.Sort(result.VertexCover)
+
+	result.Message = fmt.Sprintf("Maximum matching has %d edge(s), minimum vertex cover has %d vertices",
+		size, len(result.VertexCover))
+
+	return result, nil
+}
+
+// FormatBipartiteResult creates a formatted string representation
+func (result *BipartiteResult) FormatBipartiteResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("BIPARTITENESS CHECK\n\n")
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Total edges: %d\n\n", len(gr.Edges)))
+
+	if !result.IsBipartite {
+		sb.WriteString("Graph is NOT bipartite\n\n")
+		sb.WriteString(fmt.Sprintf("ODD CYCLE (length %d):\n", len(result.OddCycleEdges)))
+		cycle := append(slices.Clone(result.OddCycle), result.OddCycle[0])
+		sb.WriteString(fmt.Sprintf("%s\n", formatTrail(cycle)))
+		sb.WriteString(fmt.Sprintf("Edges: %s\n", formatKeyList(result.OddCycleEdges)))
+		return sb.String()
+	}
+
+	sb.WriteString("Graph is bipartite\n\n")
+	sb.WriteString(fmt.Sprintf("LEFT SIDE (%d): %s\n", len(result.Left), formatKeyList(result.Left)))
+	sb.WriteString(fmt.Sprintf("RIGHT SIDE (%d): %s\n", len(result.Right), formatKeyList(result.Right)))
+
+	return sb.String()
+}
+
+// FormatMatchingResult creates a formatted string representation
+func (result *MatchingResult) FormatMatchingResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("MAXIMUM MATCHING (%s)\n\n", result.Algorithm))
+	if len(result.Left) > 0 || len(result.Right) > 0 {
+		sb.WriteString(fmt.Sprintf("Left side (%d): %s\n", len(result.Left), formatKeyList(result.Left)))
+		sb.WriteString(fmt.Sprintf("Right side (%d): %s\n", len(result.Right), formatKeyList(result.Right)))
+	}
+	sb.WriteString(fmt.Sprintf("Matching size: %d\n\n", result.Size))
+
+	sb.WriteString("MATCHED EDGES:\n")
+	sb.WriteString(fmt.Sprintf("%-8s %-20s %-20s\n", "Edge", "From", "To"))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for _, key := range result.Edges {
+		edge := gr.Edges[key]
+		sb.WriteString(fmt.Sprintf("%-8d %-20s %-20s\n",
+			key, formatNodeName(gr, edge.Source), formatNodeName(gr, edge.Destination)))
+	}
+
+	unmatched := []graph.TKey{}
+	for _, key := range getSortedKeys(gr.Nodes) {
+		if _, ok := result.Mate[key]; !ok {
+			unmatched = append(unmatched, key)
+		}
+	}
+	sb.WriteString(fmt.Sprintf("\nUnmatched vertices (%d): %s\n", len(unmatched), formatKeyList(unmatched)))
+
+	if result.VertexCover != nil {
+		sb.WriteString(fmt.Sprintf("\nMINIMUM VERTEX COVER (König, %d): %s\n", len(result.VertexCover), formatKeyList(result.VertexCover)))
+	}
+
+	return sb.String()
+}
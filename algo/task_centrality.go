@@ -0,0 +1,676 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Rank nodes by centrality
+ *
+ * Betweenness - share of shortest paths between other pairs passing through
+ * a node (or an edge). Brandes' Algorithm counts shortest paths σ from every
+ * source with BFS (Dijkstra for weighted graphs), then accumulates dependency
+ * δ(v) = Σ σ(v) / σ(w) * (1 + δ(w)) over successors w in reverse order.
+ * Time Complexity: O(V * E), O(V * E log V) weighted.
+ *
+ * Closeness - how near a node is to others: (r - 1) / Σ d(v, u) scaled by
+ * (r - 1) / (V - 1), where r counts reachable nodes (Wasserman-Faust, so it
+ * works for disconnected graphs too). Harmonic centrality is Σ 1 / d(v, u),
+ * unreachable nodes just add 0. Distances go out of the node.
+ *
+ * PageRank - random surfer follows an outgoing edge with probability d
+ * (damping), or jumps to a random node with probability 1 - d. Jumps and
+ * walks out of dead ends follow the personalization vector, uniform by
+ * default. Eigenvector centrality - node is important if its in-neighbors are,
+ * the main eigenvector of adjacency matrix; iterated as x + A^T x, which has
+ * the same eigenvector but also converges on bipartite graphs. HITS - good
+ * hubs point to good authorities, good authorities are pointed by good hubs.
+ * All three are found by power iteration.
+ *
+ * Undirected edges work in both directions. Weighted mode uses edge weights
+ * as lengths for paths and as strengths for PageRank, eigenvector and HITS;
+ * they must be positive then.
+ */
+
+// CentralityMeasure names a centrality measure
+type CentralityMeasure string
+
+const (
+	CentralityBetweenness     CentralityMeasure = "Betweenness"
+	CentralityEdgeBetweenness CentralityMeasure = "Edge Betweenness"
+	CentralityCloseness       CentralityMeasure = "Closeness"
+	CentralityHarmonic        CentralityMeasure = "Harmonic"
+	CentralityPageRank        CentralityMeasure = "PageRank"
+	CentralityEigenvector     CentralityMeasure = "Eigenvector"
+	CentralityHubs            CentralityMeasure = "HITS Hubs"
+	CentralityAuthorities     CentralityMeasure = "HITS Authorities"
+)
+
+// CentralityOptions configures centrality measures
+type CentralityOptions struct {
+	Weighted        bool                   // Use edge weights instead of counting edges
+	Normalized      bool                   // Scale betweenness and harmonic centrality to [0, 1]
+	Damping         float64                // PageRank probability of following an edge
+	Personalization map[graph.TKey]float64 // PageRank jump preferences, uniform if empty
+	MaxIterations   int                    // Power iteration limit
+	Tolerance       float64                // Power iteration stops when scores change less
+}
+
+func WithCentralityWeighted(weighted bool) graph.Option[CentralityOptions] {
+	return func(opts *CentralityOptions) {
+		opts.Weighted = weighted
+	}
+}
+
+func WithCentralityNormalized(normalized bool) graph.Option[CentralityOptions] {
+	return func(opts *CentralityOptions) {
+		opts.Normalized = normalized
+	}
+}
+
+func WithDamping(damping float64) graph.Option[CentralityOptions] {
+	return func(opts *CentralityOptions) {
+		opts.Damping = damping
+	}
+}
+
+func WithPersonalization(personalization map[graph.TKey]float64) graph.Option[CentralityOptions] {
+	return func(opts *CentralityOptions) {
+		opts.Personalization = personalization
+	}
+}
+
+func WithMaxIterations(iterations int) graph.Option[CentralityOptions] {
+	return func(opts *CentralityOptions) {
+		opts.MaxIterations = iterations
+	}
+}
+
+func WithTolerance(tolerance float64) graph.Option[CentralityOptions] {
+	return func(opts *CentralityOptions) {
+		opts.Tolerance = tolerance
+	}
+}
+
+// CentralityResult represents scores of nodes (or edges for edge betweenness)
+type CentralityResult struct {
+	Measure    CentralityMeasure      // What was computed
+	Scores     map[graph.TKey]float64 // Score of every node or edge
+	Ranking    []graph.TKey           // Keys by score, highest first
+	IsEdges    bool                   // Scores belong to edges, not nodes
+	Iterations int                    // Power iterations done, 0 for exact measures
+	Converged  bool                   // Power iteration reached tolerance
+	Message    string                 // Status message
+}
+
+// centralityArc is an arc between node indices
+type centralityArc struct {
+	to     int
+	key    graph.TKey
+	weight int64
+}
+
+// centralityGraph is the graph on indices with outgoing and incoming arcs
+type centralityGraph struct {
+	keys []graph.TKey
+	out  [][]centralityArc
+	in   [][]centralityArc // to is the tail of the arc here
+}
+
+// parseCentralityOptions applies options over defaults
+func parseCentralityOptions(options []graph.Option[CentralityOptions]) (CentralityOptions, error) {
+	opts := CentralityOptions{Damping: 0.85, MaxIterations: 100, Tolerance: 1e-6}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.Damping < 0 || opts.Damping > 1 {
+		return opts, fmt.Errorf("damping must be between 0 and 1, got %g", opts.Damping)
+	}
+	if opts.MaxIterations <= 0 {
+		return opts, fmt.Errorf("iteration limit must be positive, got %d", opts.MaxIterations)
+	}
+	return opts, nil
+}
+
+// buildCentralityGraph indexes nodes and builds arcs, skipping loops
+func buildCentralityGraph(gr *graph.Graph, weighted bool) (*centralityGraph, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	cg := &centralityGraph{keys: keys, out: make([][]centralityArc, len(keys)), in: make([][]centralityArc, len(keys))}
+	addArc := func(u, v int, key graph.TKey, weight int64) {
+		cg.out[u] = append(cg.out[u], centralityArc{to: v, key: key, weight: weight})
+		cg.in[v] = append(cg.in[v], centralityArc{to: u, key: key, weight: weight})
+	}
+
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		if edge.Source == edge.Destination {
+			continue
+		}
+
+		weight := int64(1)
+		if weighted {
+			if edge.Weight <= 0 {
+				return nil, fmt.Errorf("weighted centrality needs positive weights, edge %d has weight %d", key, edge.Weight)
+			}
+			weight = int64(edge.Weight)
+		}
+
+		u, v := index[edge.Source], index[edge.Destination]
+		addArc(u, v, key, weight)
+		if !gr.Options.IsDirected {
+			addArc(v, u, key, weight)
+		}
+	}
+	return cg, nil
+}
+
+// shortestPathCounts is what Brandes' algorithm learns from a single source
+type shortestPathCounts struct {
+	order []int             // Reached vertices by non-decreasing distance
+	dist  []int64           // Distance, -1 if not reached
+	sigma []float64         // Number of shortest paths
+	pred  [][]centralityArc // Last arcs of shortest paths, to is the previous vertex
+}
+
+// countShortestPaths runs BFS (or Dijkstra when weighted) counting shortest paths from source
+func (cg *centralityGraph) countShortestPaths(source int, weighted bool) *shortestPathCounts {
+	n := len(cg.keys)
+	counts := &shortestPathCounts{
+		dist:  make([]int64, n),
+		sigma: make([]float64, n),
+		pred:  make([][]centralityArc, n),
+	}
+	for i := range counts.dist {
+		counts.dist[i] = -1
+	}
+	counts.dist[source] = 0
+	counts.sigma[source] = 1
+
+	// relax handles arc u → v found at distance d
+	relax := func(u int, arc centralityArc, d int64) bool {
+		v := arc.to
+		switch {
+		case counts.dist[v] == -1 || d < counts.dist[v]:
+			counts.dist[v] = d
+			counts.sigma[v] = counts.sigma[u]
+			counts.pred[v] = []centralityArc{{to: u, key: arc.key}}
+			return true
+		case d == counts.dist[v]:
+			counts.sigma[v] += counts.sigma[u]
+			counts.pred[v] = append(counts.pred[v], centralityArc{to: u, key: arc.key})
+		}
+		return false
+	}
+
+	if !weighted {
+		queue := []int{source}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			counts.order = append(counts.order, u)
+			for _, arc := range cg.out[u] {
+				if relax(u, arc, counts.dist[u]+1) {
+					queue = append(queue, arc.to)
+				}
+			}
+		}
+		return counts
+	}
+
+	settled := make([]bool, n)
+	pq := &distanceHeap{{vertex: graph.TKey(source), dist: 0}}
+	for pq.Len() > 0 {
+		u := int(heap.Pop(pq).(distanceItem).vertex)
+		if settled[u] {
+			continue
+		}
+		settled[u] = true
+		counts.order = append(counts.order, u)
+
+		for _, arc := range cg.out[u] {
+			if relax(u, arc, counts.dist[u]+arc.weight) {
+				heap.Push(pq, distanceItem{vertex: graph.TKey(arc.to), dist: counts.dist[arc.to]})
+			}
+		}
+	}
+	return counts
+}
+
+// brandes returns betweenness of nodes (by index) and of edges (by key)
+func (cg *centralityGraph) brandes(weighted, undirected bool) ([]float64, map[graph.TKey]float64) {
+	nodeScores := make([]float64, len(cg.keys))
+	edgeScores := make(map[graph.TKey]float64)
+	delta := make([]float64, len(cg.keys))
+
+	for source := range cg.keys {
+		counts := cg.countShortestPaths(source, weighted)
+
+		// Dependencies accumulate from the farthest vertices back to the source
+		clear(delta)
+		for i := len(counts.order) - 1; i >= 0; i-- {
+			w := counts.order[i]
+			for _, arc := range counts.pred[w] {
+				share := counts.sigma[arc.to] / counts.sigma[w] * (1 + delta[w])
+				edgeScores[arc.key] += share
+				delta[arc.to] += share
+			}
+			if w != source {
+				nodeScores[w] += delta[w]
+			}
+		}
+	}
+
+	// Every pair was counted from both ends
+	if undirected {
+		for i := range nodeScores {
+			nodeScores[i] /= 2
+		}
+		for key := range edgeScores {
+			edgeScores[key] /= 2
+		}
+	}
+	return nodeScores, edgeScores
+}
+
+// FindBetweenness finds betweenness centrality of every node using Brandes' algorithm
+func FindBetweenness(gr *graph.Graph, options ...graph.Option[CentralityOptions]) (*CentralityResult, error) {
+	opts, err := parseCentralityOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	cg, err := buildCentralityGraph(gr, opts.Weighted)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeScores, _ := cg.brandes(opts.Weighted, !gr.Options.IsDirected)
+
+	// Pairs of other nodes: (n - 1)(n - 2), unordered ones are half as many
+	n := float64(len(cg.keys))
+	scale := 1.0
+	if opts.Normalized && n > 2 {
+		scale = 1 / ((n - 1) * (n - 2))
+		if !gr.Options.IsDirected {
+			scale *= 2
+		}
+	}
+
+	scores := make(map[graph.TKey]float64, len(cg.keys))
+	for i, key := range cg.keys {
+		scores[key] = nodeScores[i] * scale
+	}
+	return makeCentralityResult(CentralityBetweenness, scores, false), nil
+}
+
+// FindEdgeBetweenness finds betweenness centrality of every edge using Brandes' algorithm
+func FindEdgeBetweenness(gr *graph.Graph, options ...graph.Option[CentralityOptions]) (*CentralityResult, error) {
+	opts, err := parseCentralityOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	cg, err := buildCentralityGraph(gr, opts.Weighted)
+	if err != nil {
+		return nil, err
+	}
+
+	_, edgeScores := cg.brandes(opts.Weighted, !gr.Options.IsDirected)
+
+	// All pairs of nodes: n(n - 1), unordered ones are half as many
+	n := float64(len(cg.keys))
+	scale := 1.0
+	if opts.Normalized && n > 1 {
+		scale = 1 / (n * (n - 1))
+		if !gr.Options.IsDirected {
+			scale *= 2
+		}
+	}
+
+	scores := make(map[graph.TKey]float64, len(gr.Edges))
+	for key := range gr.Edges {
+		scores[key] = edgeScores[key] * scale
+	}
+	return makeCentralityResult(CentralityEdgeBetweenness, scores, true), nil
+}
+
+// FindCloseness finds closeness centrality of every node, Wasserman-Faust variant
+func FindCloseness(gr *graph.Graph, options ...graph.Option[CentralityOptions]) (*CentralityResult, error) {
+	return findDistanceCentrality(gr, CentralityCloseness, options)
+}
+
+// FindHarmonicCentrality finds sum of inverse distances from every node
+func FindHarmonicCentrality(gr *graph.Graph, options ...graph.Option[CentralityOptions]) (*CentralityResult, error) {
+	return findDistanceCentrality(gr, CentralityHarmonic, options)
+}
+
+// findDistanceCentrality computes closeness or harmonic centrality from single-source distances
+func findDistanceCentrality(gr *graph.Graph, measure CentralityMeasure, options []graph.Option[CentralityOptions]) (*CentralityResult, error) {
+	opts, err := parseCentralityOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	cg, err := buildCentralityGraph(gr, opts.Weighted)
+	if err != nil {
+		return nil, err
+	}
+
+	others := float64(len(cg.keys) - 1)
+	scores := make(map[graph.TKey]float64, len(cg.keys))
+	for source, key := range cg.keys {
+		counts := cg.countShortestPaths(source, opts.Weighted)
+
+		total, harmonic := 0.0, 0.0
+		for _, v := range counts.order[1:] {
+			total += float64(counts.dist[v])
+			harmonic += 1 / float64(counts.dist[v])
+		}
+		reached := float64(len(counts.order) - 1)
+
+		switch {
+		case measure == CentralityHarmonic:
+			scores[key] = harmonic
+			if opts.Normalized && others > 0 {
+				scores[key] /= others
+			}
+		case total > 0:
+			// Closeness is always normalized, the scale makes it comparable across components
+			scores[key] = reached / total * (reached / others)
+		}
+	}
+	return makeCentralityResult(measure, scores, false), nil
+}
+
+// FindPageRank finds PageRank of every node by power iteration
+func FindPageRank(gr *graph.Graph, options ...graph.Option[CentralityOptions]) (*CentralityResult, error) {
+	opts, err := parseCentralityOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	cg, err := buildCentralityGraph(gr, opts.Weighted)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(cg.keys)
+	if n == 0 {
+		return makeCentralityResult(CentralityPageRank, map[graph.TKey]float64{}, false), nil
+	}
+
+	// Jump distribution
+	jump := make([]float64, n)
+	if len(opts.Personalization) == 0 {
+		for i := range jump {
+			jump[i] = 1 / float64(n)
+		}
+	} else {
+		total := 0.0
+		for i, key := range cg.keys {
+			value := opts.Personalization[key]
+			if value < 0 {
+				return nil, fmt.Errorf("personalization of node %d is negative", key)
+			}
+			jump[i] = value
+			total += value
+		}
+		for key := range opts.Personalization {
+			if _, err := gr.GetNodeByKey(key); err != nil {
+				return nil, fmt.Errorf("personalization node %d does not exist", key)
+			}
+		}
+		if total == 0 {
+			return nil, fmt.Errorf("personalization must give positive value to some node")
+		}
+		for i := range jump {
+			jump[i] /= total
+		}
+	}
+
+	outWeight := make([]float64, n)
+	for u := range cg.out {
+		for _, arc := range cg.out[u] {
+			outWeight[u] += float64(arc.weight)
+		}
+	}
+
+	rank := append([]float64{}, jump...)
+	next := make([]float64, n)
+	result := &CentralityResult{Measure: CentralityPageRank}
+	for result.Iterations < opts.MaxIterations {
+		result.Iterations++
+
+		// Dead ends give their rank away the same way as jumps
+		dangling := 0.0
+		for u := range rank {
+			if outWeight[u] == 0 {
+				dangling += rank[u]
+			}
+		}
+		for v := range next {
+			next[v] = ((1 - opts.Damping) + opts.Damping*dangling) * jump[v]
+			for _, arc := range cg.in[v] {
+				next[v] += opts.Damping * rank[arc.to] * float64(arc.weight) / outWeight[arc.to]
+			}
+		}
+
+		change := 0.0
+		for v := range rank {
+			change += math.Abs(next[v] - rank[v])
+		}
+		rank, next = next, rank
+		if change < opts.Tolerance*float64(n) {
+			result.Converged = true
+			break
+		}
+	}
+
+	return finishIterativeCentrality(result, cg.keys, rank), nil
+}
+
+// FindEigenvectorCentrality finds eigenvector centrality of every node by power iteration
+func FindEigenvectorCentrality(gr *graph.Graph, options ...graph.Option[CentralityOptions]) (*CentralityResult, error) {
+	opts, err := parseCentralityOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	cg, err := buildCentralityGraph(gr, opts.Weighted)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(cg.keys)
+	score := make([]float64, n)
+	for i := range score {
+		score[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+
+	result := &CentralityResult{Measure: CentralityEigenvector}
+	for n > 0 && result.Iterations < opts.MaxIterations {
+		result.Iterations++
+
+		for v := range next {
+			next[v] = score[v]
+			for _, arc := range cg.in[v] {
+				next[v] += score[arc.to] * float64(arc.weight)
+			}
+		}
+		normalizeVector(next, 2)
+
+		change := 0.0
+		for v := range score {
+			change += math.Abs(next[v] - score[v])
+		}
+		score, next = next, score
+		if change < opts.Tolerance*float64(n) {
+			result.Converged = true
+			break
+		}
+	}
+
+	return finishIterativeCentrality(result, cg.keys, score), nil
+}
+
+// FindHITS finds hub and authority scores of every node by power iteration
+func FindHITS(gr *graph.Graph, options ...graph.Option[CentralityOptions]) (*CentralityResult, *CentralityResult, error) {
+	opts, err := parseCentralityOptions(options)
+	if err != nil {
+		return nil, nil, err
+	}
+	cg, err := buildCentralityGraph(gr, opts.Weighted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n := len(cg.keys)
+	hubs := make([]float64, n)
+	for i := range hubs {
+		hubs[i] = 1 / float64(n)
+	}
+	authorities := make([]float64, n)
+	nextHubs := make([]float64, n)
+
+	hubResult := &CentralityResult{Measure: CentralityHubs}
+	for n > 0 && hubResult.Iterations < opts.MaxIterations {
+		hubResult.Iterations++
+
+		for v := range authorities {
+			authorities[v] = 0
+			for _, arc := range cg.in[v] {
+				authorities[v] += hubs[arc.to] * float64(arc.weight)
+			}
+		}
+		normalizeVector(authorities, 1)
+
+		for u := range nextHubs {
+			nextHubs[u] = 0
+			for _, arc := range cg.out[u] {
+				nextHubs[u] += authorities[arc.to] * float64(arc.weight)
+			}
+		}
+		normalizeVector(nextHubs, 1)
+
+		change := 0.0
+		for u := range hubs {
+			change += math.Abs(nextHubs[u] - hubs[u])
+		}
+		hubs, nextHubs = nextHubs, hubs
+		if change < opts.Tolerance*float64(n) {
+			hubResult.Converged = true
+			break
+		}
+	}
+
+	authorityResult := &CentralityResult{
+		Measure:    CentralityAuthorities,
+		Iterations: hubResult.Iterations,
+		Converged:  hubResult.Converged,
+	}
+	return finishIterativeCentrality(hubResult, cg.keys, hubs),
+		finishIterativeCentrality(authorityResult, cg.keys, authorities), nil
+}
+
+// normalizeVector scales vector to unit L1 or L2 norm, zero vector stays zero
+func normalizeVector(vector []float64, norm int) {
+	total := 0.0
+	for _, value := range vector {
+		if norm == 1 {
+			total += math.Abs(value)
+		} else {
+			total += value * value
+		}
+	}
+	if norm == 2 {
+		total = math.Sqrt(total)
+	}
+	if total == 0 {
+		return
+	}
+	for i := range vector {
+		vector[i] /= total
+	}
+}
+
+// finishIterativeCentrality fills scores, ranking and message of power iteration result
+func finishIterativeCentrality(result *CentralityResult, keys []graph.TKey, values []float64) *CentralityResult {
+	scores := make(map[graph.TKey]float64, len(keys))
+	for i, key := range keys {
+		scores[key] = values[i]
+	}
+	filled := makeCentralityResult(result.Measure, scores, false)
+	filled.Iterations = result.Iterations
+	filled.Converged = result.Converged || len(keys) == 0
+	if !filled.Converged {
+		filled.Message = fmt.Sprintf("%s did not converge in %d iterations", result.Measure, result.Iterations)
+	}
+	return filled
+}
+
+// makeCentralityResult ranks scores, highest first and by key on ties
+func makeCentralityResult(measure CentralityMeasure, scores map[graph.TKey]float64, isEdges bool) *CentralityResult {
+	ranking := getSortedMapKeys(scores)
+	sort.SliceStable(ranking, func(i, j int) bool { return scores[ranking[i]] > scores[ranking[j]] })
+
+	result := &CentralityResult{
+		Measure:   measure,
+		Scores:    scores,
+		Ranking:   ranking,
+		IsEdges:   isEdges,
+		Converged: true,
+	}
+	if len(ranking) > 0 {
+		kind := "Node"
+		if isEdges {
+			kind = "Edge"
+		}
+		result.Message = fmt.Sprintf("%s: %s %d ranks first with %.4f", measure, kind, ranking[0], scores[ranking[0]])
+	} else {
+		result.Message = fmt.Sprintf("%s: graph is empty", measure)
+	}
+	return result
+}
+
+// FormatCentralityResult creates a formatted string representation, sorted by score
+func (result *CentralityResult) FormatCentralityResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s CENTRALITY\n\n", strings.ToUpper(string(result.Measure))))
+	if result.Iterations > 0 {
+		sb.WriteString(fmt.Sprintf("Iterations: %d, converged: %v\n\n", result.Iterations, result.Converged))
+	}
+
+	if result.IsEdges {
+		sb.WriteString(fmt.Sprintf("%-6s %-8s %-8s %-8s %-12s\n", "Rank", "Edge", "From", "To", "Score"))
+		sb.WriteString(strings.Repeat("─", 46) + "\n")
+		for i, key := range result.Ranking {
+			edge := gr.Edges[key]
+			sb.WriteString(fmt.Sprintf("%-6d %-8d %-8d %-8d %-12.6f\n", i+1, key, edge.Source, edge.Destination, result.Scores[key]))
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%-6s %-20s %-12s\n", "Rank", "Node", "Score"))
+	sb.WriteString(strings.Repeat("─", 40) + "\n")
+	for i, key := range result.Ranking {
+		sb.WriteString(fmt.Sprintf("%-6d %-20s %-12.6f\n", i+1, formatNodeName(gr, key), result.Scores[key]))
+	}
+
+	return sb.String()
+}
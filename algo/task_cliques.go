@@ -0,0 +1,398 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find cliques, independent sets and vertex covers of undirected graph
+ *
+ * Clique - set of pairwise adjacent vertices. Independent set - set of pairwise
+ * non-adjacent vertices, that is a clique of the complement graph. Vertex cover
+ * - set touching every edge, that is the complement of an independent set. So
+ * all three are the same problem, NP-hard in general.
+ *
+ * Bron-Kerbosch Algorithm with pivoting - enumerates maximal cliques. Keeps
+ * clique R, candidates P and excluded X. Vertices adjacent to pivot u are not
+ * branched on, since any maximal clique avoiding them contains u or one of its
+ * non-neighbors. Choosing u with the most neighbors in P gives O(3^(V/3)).
+ *
+ * Maximum clique - branch and bound (Tomita's MCQ). Candidates are greedily
+ * colored, and a clique takes at most one vertex of each color, so the number
+ * of colors bounds what is left to gain. All sets are bitsets.
+ *
+ * Graphs larger than the exact limit get approximations instead:
+ *   - vertex cover: both ends of a maximal matching, at most twice the optimum
+ *   - independent set: greedy, vertex of the smallest remaining degree first
+ *
+ * Parallel edges do not matter. A loop does: its vertex has to be in every
+ * vertex cover and cannot be in an independent set.
+ */
+
+// DefaultExactLimit is the largest number of vertices solved exactly by default
+const DefaultExactLimit = 60
+
+// VertexSetOptions configures independent set and vertex cover search
+type VertexSetOptions struct {
+	ExactLimit int // Graphs with more vertices get approximate answer
+}
+
+func WithExactLimit(limit int) graph.Option[VertexSetOptions] {
+	return func(opts *VertexSetOptions) {
+		opts.ExactLimit = limit
+	}
+}
+
+// CliquesResult represents all maximal cliques of the graph
+type CliquesResult struct {
+	Cliques       [][]graph.TKey // Maximal cliques, each sorted, largest first
+	MaximumClique []graph.TKey   // One of the largest cliques
+	Message       string         // Status message
+}
+
+// VertexSetResult represents clique, independent set or vertex cover
+type VertexSetResult struct {
+	Vertices  []graph.TKey // Vertices of the set, sorted
+	IsExact   bool         // Set is optimal, not approximate
+	Algorithm string       // Algorithm used
+	Message   string       // Status message
+}
+
+// simpleGraph is undirected graph on indices 0..n-1 without loops and parallel edges
+type simpleGraph struct {
+	keys  []graph.TKey
+	adj   []bitset
+	loops bitset // Vertices with a loop
+}
+
+// buildSimpleGraph converts undirected graph to bitset adjacency
+func buildSimpleGraph(gr *graph.Graph) (*simpleGraph, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if gr.Options.IsDirected {
+		return nil, graph.ThrowGraphDirected()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	sg := &simpleGraph{keys: keys, adj: make([]bitset, len(keys)), loops: makeBitset(len(keys))}
+	for i := range sg.adj {
+		sg.adj[i] = makeBitset(len(keys))
+	}
+	for _, edge := range gr.Edges {
+		u, v := index[edge.Source], index[edge.Destination]
+		if u == v {
+			sg.loops.add(u)
+			continue
+		}
+		sg.adj[u].add(v)
+		sg.adj[v].add(u)
+	}
+	return sg, nil
+}
+
+// toKeys converts indices to sorted keys
+func (sg *simpleGraph) toKeys(indices []int) []graph.TKey {
+	result := make([]graph.TKey, len(indices))
+	for i, v := range indices {
+		result[i] = sg.keys[v]
+	}
+	slices.Sort(result)
+	return result
+}
+
+// all returns bitset of all vertices
+func (sg *simpleGraph) all() bitset {
+	set := makeBitset(len(sg.keys))
+	for i := range sg.keys {
+		set.add(i)
+	}
+	return set
+}
+
+// complement returns adjacency of complement graph
+func (sg *simpleGraph) complement() []bitset {
+	all := sg.all()
+	result := make([]bitset, len(sg.adj))
+	for v := range sg.adj {
+		result[v] = all.clone()
+		result[v].andNot(sg.adj[v])
+		result[v].remove(v)
+	}
+	return result
+}
+
+// FindMaximalCliques enumerates all maximal cliques using Bron-Kerbosch algorithm with pivoting
+func FindMaximalCliques(gr *graph.Graph) (*CliquesResult, error) {
+	sg, err := buildSimpleGraph(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CliquesResult{Cliques: [][]graph.TKey{}, MaximumClique: []graph.TKey{}}
+	var bronKerbosch func(clique []int, candidates, excluded bitset)
+	bronKerbosch = func(clique []int, candidates, excluded bitset) {
+		if candidates.isEmpty() && excluded.isEmpty() {
+			result.Cliques = append(result.Cliques, sg.toKeys(clique))
+			return
+		}
+
+		// Pivot with the most neighbors among candidates
+		pivot, pivotCount := -1, -1
+		for _, bucket := range []bitset{candidates, excluded} {
+			for _, u := range bucket.elements() {
+				if count := candidates.intersectionCount(sg.adj[u]); count > pivotCount {
+					pivot, pivotCount = u, count
+				}
+			}
+		}
+
+		branches := candidates.clone()
+		branches.andNot(sg.adj[pivot])
+		for _, v := range branches.elements() {
+			nextCandidates := candidates.clone()
+			nextCandidates.and(sg.adj[v])
+			nextExcluded := excluded.clone()
+			nextExcluded.and(sg.adj[v])
+
+			bronKerbosch(append(clique, v), nextCandidates, nextExcluded)
+
+			candidates.remove(v)
+			excluded.add(v)
+		}
+	}
+
+	if len(sg.keys) > 0 {
+		bronKerbosch([]int{}, sg.all(), makeBitset(len(sg.keys)))
+	}
+
+	slices.SortStableFunc(result.Cliques, func(a, b []graph.TKey) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return slices.Compare(a, b)
+	})
+	if len(result.Cliques) > 0 {
+		result.MaximumClique = result.Cliques[0]
+	}
+	result.Message = fmt.Sprintf("Found %d maximal clique(s), the largest has %d vertices",
+		len(result.Cliques), len(result.MaximumClique))
+
+	return result, nil
+}
+
+// FindMaximumClique finds one of the largest cliques by branch and bound
+func FindMaximumClique(gr *graph.Graph) (*VertexSetResult, error) {
+	sg, err := buildSimpleGraph(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	clique := sg.toKeys(maximumClique(sg.adj, sg.all()))
+	return &VertexSetResult{
+		Vertices:  clique,
+		IsExact:   true,
+		Algorithm: "Branch and bound (MCQ)",
+		Message:   fmt.Sprintf("Maximum clique has %d vertices", len(clique)),
+	}, nil
+}
+
+// FindMaximumIndependentSet finds the largest set of pairwise non-adjacent vertices.
+// Exact for graphs up to the exact limit, greedy otherwise
+func FindMaximumIndependentSet(gr *graph.Graph, options ...graph.Option[VertexSetOptions]) (*VertexSetResult, error) {
+	opts := VertexSetOptions{ExactLimit: DefaultExactLimit}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	sg, err := buildSimpleGraph(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Vertices with loops are adjacent to themselves
+	candidates := sg.all()
+	candidates.andNot(sg.loops)
+
+	result := &VertexSetResult{}
+	if len(sg.keys) <= opts.ExactLimit {
+		result.Vertices = sg.toKeys(maximumClique(sg.complement(), candidates))
+		result.IsExact = true
+		result.Algorithm = "Branch and bound on complement"
+	} else {
+		result.Vertices = sg.toKeys(greedyIndependentSet(sg.adj, candidates))
+		result.Algorithm = "Greedy minimum degree"
+	}
+	result.Message = fmt.Sprintf("%s independent set has %d vertices", describeExactness(result.IsExact), len(result.Vertices))
+
+	return result, nil
+}
+
+// FindMinimumVertexCover finds the smallest set of vertices touching every edge.
+// Exact for graphs up to the exact limit, 2-approximation otherwise
+func FindMinimumVertexCover(gr *graph.Graph, options ...graph.Option[VertexSetOptions]) (*VertexSetResult, error) {
+	opts := VertexSetOptions{ExactLimit: DefaultExactLimit}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	sg, err := buildSimpleGraph(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VertexSetResult{}
+	if len(sg.keys) <= opts.ExactLimit {
+		// Cover is everything outside the maximum independent set
+		candidates := sg.all()
+		candidates.andNot(sg.loops)
+		cover := sg.all()
+		for _, v := range maximumClique(sg.complement(), candidates) {
+			cover.remove(v)
+		}
+		result.Vertices = sg.toKeys(cover.elements())
+		result.IsExact = true
+		result.Algorithm = "Branch and bound on complement"
+	} else {
+		// Both ends of every edge of a maximal matching, plus loops
+		cover := sg.loops.clone()
+		for u := range sg.adj {
+			if cover.has(u) {
+				continue
+			}
+			free := sg.adj[u].clone()
+			free.andNot(cover)
+			if v := free.first(); v != -1 {
+				cover.add(u)
+				cover.add(v)
+			}
+		}
+		result.Vertices = sg.toKeys(cover.elements())
+		result.Algorithm = "Maximal matching (2-approximation)"
+	}
+	result.Message = fmt.Sprintf("%s vertex cover has %d vertices", describeExactness(result.IsExact), len(result.Vertices))
+
+	return result, nil
+}
+
+// maximumClique finds the largest clique among candidates
+func maximumClique(adj []bitset, candidates bitset) []int {
+	best := []int{}
+
+	var expand func(clique []int, candidates bitset)
+	expand = func(clique []int, candidates bitset) {
+		order, bounds := colorSortCandidates(adj, candidates)
+		for i := len(order) - 1; i >= 0; i-- {
+			if len(clique)+bounds[i] <= len(best) {
+				return // Even taking one vertex of every color is not enough
+			}
+
+			v := order[i]
+			next := candidates.clone()
+			next.and(adj[v])
+			grown := append(clique, v)
+			if next.isEmpty() {
+				if len(grown) > len(best) {
+					best = slices.Clone(grown)
+				}
+			} else {
+				expand(grown, next)
+			}
+			candidates.remove(v)
+		}
+	}
+
+	expand([]int{}, candidates.clone())
+	return best
+}
+
+// colorSortCandidates greedily colors candidates, returning them by color with the color number
+func colorSortCandidates(adj []bitset, candidates bitset) ([]int, []int) {
+	order := make([]int, 0, candidates.count())
+	bounds := make([]int, 0, cap(order))
+
+	uncolored := candidates.clone()
+	for color := 1; !uncolored.isEmpty(); color++ {
+		available := uncolored.clone()
+		for v := available.first(); v != -1; v = available.first() {
+			available.remove(v)
+			available.andNot(adj[v])
+			uncolored.remove(v)
+			order = append(order, v)
+			bounds = append(bounds, color)
+		}
+	}
+	return order, bounds
+}
+
+// greedyIndependentSet takes vertex of the smallest degree and drops its neighbors, until nothing is left
+func greedyIndependentSet(adj []bitset, candidates bitset) []int {
+	remaining := candidates.clone()
+	result := []int{}
+	for !remaining.isEmpty() {
+		best, bestDegree := -1, 0
+		for _, v := range remaining.elements() {
+			if degree := remaining.intersectionCount(adj[v]); best == -1 || degree < bestDegree {
+				best, bestDegree = v, degree
+			}
+		}
+		result = append(result, best)
+		remaining.remove(best)
+		remaining.andNot(adj[best])
+	}
+	return result
+}
+
+// describeExactness returns message prefix telling if the set is optimal
+func describeExactness(isExact bool) string {
+	if isExact {
+		return "Optimal"
+	}
+	return "Approximate"
+}
+
+// FormatCliquesResult creates a formatted string representation
+func (result *CliquesResult) FormatCliquesResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("MAXIMAL CLIQUES (Bron-Kerbosch with pivoting)\n\n")
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Maximal cliques: %d\n", len(result.Cliques)))
+	sb.WriteString(fmt.Sprintf("Maximum clique (%d): %s\n\n", len(result.MaximumClique), formatKeyList(result.MaximumClique)))
+
+	const shown = 100
+	sb.WriteString("CLIQUES:\n")
+	for i, clique := range result.Cliques {
+		if i == shown {
+			sb.WriteString(fmt.Sprintf("... and %d more\n", len(result.Cliques)-shown))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("%d. {%s}\n", i+1, formatKeyList(clique)))
+	}
+
+	return sb.String()
+}
+
+// FormatVertexSetResult creates a formatted string representation
+func (result *VertexSetResult) FormatVertexSetResult(title string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s (%s)\n", strings.ToUpper(title), result.Algorithm))
+	sb.WriteString(fmt.Sprintf("Size: %d, optimal: %v\n", len(result.Vertices), result.IsExact))
+	sb.WriteString(fmt.Sprintf("Vertices: %s\n", formatKeyList(result.Vertices)))
+
+	return sb.String()
+}
@@ -0,0 +1,640 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Color vertices and edges of the graph
+ *
+ * Proper coloring - adjacent vertices get different colors. Chromatic number
+ * χ is the fewest colors possible. Directed graphs are colored by their
+ * underlying undirected graph, a loop makes proper coloring impossible.
+ *
+ * Greedy coloring gives every vertex the smallest color unused by neighbors,
+ * the result depends only on the order of vertices:
+ *   - Largest-First: by degree, highest first
+ *   - Smallest-Last: repeatedly remove vertex of the smallest degree, color in
+ *     reverse order. Uses at most degeneracy + 1 colors
+ *   - DSatur: next is the vertex with most distinct colors among neighbors
+ *     (saturation), ties broken by degree. Exact for bipartite graphs
+ *
+ * Exact chromatic number - branch and bound over DSatur order. A vertex gets
+ * one of used colors or one new color, branches that cannot beat the best
+ * known coloring are cut. Greedy clique is a lower bound: search stops as
+ * soon as it is reached. Exponential, so it is stopped by timeout.
+ *
+ * Edge coloring - edges sharing an end get different colors. Vizing: simple
+ * graph needs Δ or Δ + 1 colors. Misra-Gries algorithm colors edges one by
+ * one with Δ + 1 colors, rotating a fan of neighbors and swapping colors along
+ * an alternating path when needed. Time Complexity: O(V * E). Multigraphs and
+ * graphs with loops are colored greedily, with at most 2Δ - 1 colors.
+ */
+
+// ColoringOrder is a vertex order of greedy coloring
+type ColoringOrder string
+
+const (
+	ColoringLargestFirst ColoringOrder = "Largest-First"
+	ColoringSmallestLast ColoringOrder = "Smallest-Last"
+	ColoringDSatur       ColoringOrder = "DSatur"
+)
+
+// ColoringOrders lists all greedy orders, DSatur is usually the best one
+var ColoringOrders = []ColoringOrder{ColoringDSatur, ColoringLargestFirst, ColoringSmallestLast}
+
+// ColoringResult represents proper vertex coloring
+type ColoringResult struct {
+	Colors     map[graph.TKey]int // Color of every node, numbered from 0
+	NumColors  int                // Number of colors used
+	Classes    [][]graph.TKey     // Nodes of every color, sorted
+	LowerBound int                // Size of clique found, no coloring uses fewer colors
+	IsExact    bool               // NumColors is the chromatic number
+	Algorithm  string             // Algorithm used
+	Message    string             // Status message
+}
+
+// EdgeColoringResult represents proper edge coloring
+type EdgeColoringResult struct {
+	Colors    map[graph.TKey]int // Color of every edge, numbered from 0
+	NumColors int                // Number of colors used
+	MaxDegree int                // Largest vertex degree Δ
+	Classes   [][]graph.TKey     // Edges of every color (matchings), sorted
+	Algorithm string             // Algorithm used
+	Message   string             // Status message
+}
+
+// GreedyColoring colors vertices greedily in the given order
+func GreedyColoring(gr *graph.Graph, order ColoringOrder) (*ColoringResult, error) {
+	neighbors, err := buildColoringNeighbors(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	var colors map[graph.TKey]int
+	switch order {
+	case ColoringLargestFirst:
+		keys := getSortedKeys(gr.Nodes)
+		sort.SliceStable(keys, func(i, j int) bool { return len(neighbors[keys[i]]) > len(neighbors[keys[j]]) })
+		colors = colorInOrder(keys, neighbors)
+	case ColoringSmallestLast:
+		colors = colorInOrder(smallestLastOrder(gr, neighbors), neighbors)
+	case ColoringDSatur:
+		colors = colorDSatur(gr, neighbors)
+	default:
+		return nil, fmt.Errorf("unknown coloring order: %s", order)
+	}
+
+	result := makeColoringResult(colors, string(order))
+	result.LowerBound = len(greedyClique(gr, neighbors))
+	result.IsExact = result.NumColors == result.LowerBound
+	result.Message = fmt.Sprintf("%s coloring uses %d color(s)", order, result.NumColors)
+	return result, nil
+}
+
+// FindChromaticNumber finds exact chromatic number by branch and bound. When
+// timeout expires, the best coloring found so far is returned with IsExact false.
+// Zero timeout means no limit
+func FindChromaticNumber(gr *graph.Graph, timeout time.Duration) (*ColoringResult, error) {
+	neighbors, err := buildColoringNeighbors(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	// Start from DSatur coloring and a clique as bounds
+	best := colorDSatur(gr, neighbors)
+	bestCount := countColors(best)
+	lowerBound := len(greedyClique(gr, neighbors))
+
+	search := &coloringSearch{
+		adj:        make([][]int, len(keys)),
+		color:      make([]int, len(keys)),
+		seen:       make([][]int, len(keys)),
+		saturation: make([]int, len(keys)),
+		bestCount:  bestCount,
+		lowerBound: lowerBound,
+	}
+	for i, key := range keys {
+		for _, neighbor := range neighbors[key] {
+			search.adj[i] = append(search.adj[i], index[neighbor])
+		}
+		search.color[i] = -1
+		search.seen[i] = make([]int, bestCount+1)
+	}
+	if timeout > 0 {
+		search.deadline = time.Now().Add(timeout)
+	}
+
+	if bestCount > lowerBound {
+		search.branch(0, 0)
+	}
+
+	if search.best != nil {
+		best = make(map[graph.TKey]int, len(keys))
+		for i, key := range keys {
+			best[key] = search.best[i]
+		}
+	}
+
+	result := makeColoringResult(best, "Branch and bound")
+	result.LowerBound = lowerBound
+	result.IsExact = !search.timedOut || result.NumColors == lowerBound
+	if result.IsExact {
+		result.Message = fmt.Sprintf("Chromatic number is %d", result.NumColors)
+	} else {
+		result.Message = fmt.Sprintf("Timed out: chromatic number is between %d and %d", lowerBound, result.NumColors)
+	}
+	return result, nil
+}
+
+// coloringSearch keeps state of exact coloring search, vertices are indices
+type coloringSearch struct {
+	adj        [][]int
+	color      []int   // Color of vertex, -1 if not colored yet
+	seen       [][]int // How many neighbors have each color
+	saturation []int   // Number of distinct colors among neighbors
+	best       []int   // Best complete coloring found
+	bestCount  int
+	lowerBound int
+	deadline   time.Time
+	steps      int
+	timedOut   bool
+}
+
+// branch colors one more vertex, colored is the number of colored vertices, used - of colors
+func (search *coloringSearch) branch(colored, used int) {
+	if search.timedOut || search.bestCount == search.lowerBound {
+		return
+	}
+	search.steps++
+	if search.steps%1024 == 0 && !search.deadline.IsZero() && time.Now().After(search.deadline) {
+		search.timedOut = true
+		return
+	}
+
+	if colored == len(search.color) {
+		search.bestCount = used
+		search.best = append(search.best[:0], search.color...)
+		return
+	}
+
+	// DSatur choice: the most constrained vertex goes first
+	v := -1
+	for u, c := range search.color {
+		if c != -1 {
+			continue
+		}
+		if v == -1 || search.saturation[u] > search.saturation[v] ||
+			(search.saturation[u] == search.saturation[v] && len(search.adj[u]) > len(search.adj[v])) {
+			v = u
+		}
+	}
+
+	// Using a new color only helps if it still beats the best coloring
+	limit := min(used+1, search.bestCount-1)
+	for c := 0; c < limit; c++ {
+		if search.seen[v][c] > 0 {
+			continue
+		}
+
+		search.color[v] = c
+		for _, u := range search.adj[v] {
+			if search.seen[u][c] == 0 {
+				search.saturation[u]++
+			}
+			search.seen[u][c]++
+		}
+
+		search.branch(colored+1, max(used, c+1))
+
+		for _, u := range search.adj[v] {
+			search.seen[u][c]--
+			if search.seen[u][c] == 0 {
+				search.saturation[u]--
+			}
+		}
+		search.color[v] = -1
+
+		if search.timedOut || search.bestCount == search.lowerBound {
+			return
+		}
+		limit = min(limit, search.bestCount-1)
+	}
+}
+
+// FindEdgeColoring colors edges using Misra-Gries algorithm, or greedily for multigraphs
+func FindEdgeColoring(gr *graph.Graph) (*EdgeColoringResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	// Degree counts edge ends, a loop adds 2
+	degree := make(map[graph.TKey]int, len(gr.Nodes))
+	pairs := make(map[[2]graph.TKey]bool, len(gr.Edges))
+	isSimple := true
+	for _, edge := range gr.Edges {
+		degree[edge.Source]++
+		degree[edge.Destination]++
+
+		pair := [2]graph.TKey{min(edge.Source, edge.Destination), max(edge.Source, edge.Destination)}
+		if edge.Source == edge.Destination || pairs[pair] {
+			isSimple = false
+		}
+		pairs[pair] = true
+	}
+	maxDegree := 0
+	for _, d := range degree {
+		maxDegree = max(maxDegree, d)
+	}
+
+	var colors map[graph.TKey]int
+	algorithm := "Misra-Gries"
+	if isSimple {
+		colors = misraGries(gr)
+	} else {
+		algorithm = "Greedy"
+		colors = greedyEdgeColoring(gr)
+	}
+
+	numColors := countColors(colors)
+	result := &EdgeColoringResult{
+		Colors:    colors,
+		NumColors: numColors,
+		MaxDegree: maxDegree,
+		Classes:   groupByColor(colors, numColors),
+		Algorithm: algorithm,
+		Message:   fmt.Sprintf("%s edge coloring uses %d color(s), Δ = %d", algorithm, numColors, maxDegree),
+	}
+	return result, nil
+}
+
+// misraGries colors edges of simple graph with at most Δ + 1 colors
+func misraGries(gr *graph.Graph) map[graph.TKey]int {
+	const uncolored = -1
+	at := make(map[graph.TKey]map[int]graph.TKey, len(gr.Nodes)) // Color → neighbor along edge of that color
+	for key := range gr.Nodes {
+		at[key] = make(map[int]graph.TKey)
+	}
+	pairColor := make(map[[2]graph.TKey]int)
+	pair := func(u, v graph.TKey) [2]graph.TKey { return [2]graph.TKey{min(u, v), max(u, v)} }
+
+	colorOf := func(u, v graph.TKey) int {
+		if c, ok := pairColor[pair(u, v)]; ok {
+			return c
+		}
+		return uncolored
+	}
+	isFree := func(v graph.TKey, c int) bool {
+		_, taken := at[v][c]
+		return !taken
+	}
+	firstFree := func(v graph.TKey) int {
+		c := 0
+		for !isFree(v, c) {
+			c++
+		}
+		return c
+	}
+	setColor := func(u, v graph.TKey, c int) {
+		pairColor[pair(u, v)] = c
+		at[u][c] = v
+		at[v][c] = u
+	}
+	unsetColor := func(u, v graph.TKey) int {
+		c := pairColor[pair(u, v)]
+		delete(pairColor, pair(u, v))
+		delete(at[u], c)
+		delete(at[v], c)
+		return c
+	}
+
+	incidence := buildUndirectedIncidence(gr)
+
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		u, v := gr.Edges[key].Source, gr.Edges[key].Destination
+
+		// Step 1: Maximal fan of u starting at v
+		fan := []graph.TKey{v}
+		inFan := map[graph.TKey]bool{v: true}
+		for grown := true; grown; {
+			grown = false
+			last := fan[len(fan)-1]
+			for _, next := range incidence[u] {
+				c := colorOf(u, next.to)
+				if !inFan[next.to] && c != uncolored && isFree(last, c) {
+					fan = append(fan, next.to)
+					inFan[next.to] = true
+					grown = true
+					break
+				}
+			}
+		}
+
+		// Step 2: Invert cd-path from u, so that d becomes free on u
+		c, d := firstFree(u), firstFree(fan[len(fan)-1])
+		path := []graph.TKey{u}
+		for x, want := u, d; ; want = c + d - want {
+			next, ok := at[x][want]
+			if !ok {
+				break
+			}
+			path = append(path, next)
+			x = next
+		}
+		swapped := make([]int, len(path)-1)
+		for i := range swapped {
+			swapped[i] = c + d - unsetColor(path[i], path[i+1])
+		}
+		for i, color := range swapped {
+			setColor(path[i], path[i+1], color)
+		}
+
+		// Step 3: Shortest prefix of the fan ending where d is free
+		end := 0
+		for end < len(fan)-1 && !isFree(fan[end], d) {
+			end++
+		}
+
+		// Step 4: Rotate the prefix and color its last edge with d
+		shifted := make([]int, end)
+		for i := range shifted {
+			shifted[i] = unsetColor(u, fan[i+1])
+		}
+		for i, color := range shifted {
+			setColor(u, fan[i], color)
+		}
+		setColor(u, fan[end], d)
+	}
+
+	colors := make(map[graph.TKey]int, len(gr.Edges))
+	for key, edge := range gr.Edges {
+		colors[key] = colorOf(edge.Source, edge.Destination)
+	}
+	return colors
+}
+
+// greedyEdgeColoring gives every edge the smallest color unused at both of its ends
+func greedyEdgeColoring(gr *graph.Graph) map[graph.TKey]int {
+	usedAt := make(map[graph.TKey]map[int]bool, len(gr.Nodes))
+	for key := range gr.Nodes {
+		usedAt[key] = make(map[int]bool)
+	}
+
+	colors := make(map[graph.TKey]int, len(gr.Edges))
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		c := 0
+		for usedAt[edge.Source][c] || usedAt[edge.Destination][c] {
+			c++
+		}
+		colors[key] = c
+		usedAt[edge.Source][c] = true
+		usedAt[edge.Destination][c] = true
+	}
+	return colors
+}
+
+// buildColoringNeighbors returns sorted distinct neighbors in underlying undirected graph
+func buildColoringNeighbors(gr *graph.Graph) (map[graph.TKey][]graph.TKey, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	sets := make(map[graph.TKey]map[graph.TKey]bool, len(gr.Nodes))
+	for key := range gr.Nodes {
+		sets[key] = make(map[graph.TKey]bool)
+	}
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		if edge.Source == edge.Destination {
+			return nil, fmt.Errorf("node %d has a loop (edge %d), graph cannot be properly colored", edge.Source, key)
+		}
+		sets[edge.Source][edge.Destination] = true
+		sets[edge.Destination][edge.Source] = true
+	}
+
+	neighbors := make(map[graph.TKey][]graph.TKey, len(sets))
+	for key, set := range sets {
+		neighbors[key] = getSortedMapKeys(set)
+	}
+	return neighbors, nil
+}
+
+// colorInOrder gives every vertex the smallest color unused by already colored neighbors
+func colorInOrder(order []graph.TKey, neighbors map[graph.TKey][]graph.TKey) map[graph.TKey]int {
+	colors := make(map[graph.TKey]int, len(order))
+	for _, v := range order {
+		taken := make(map[int]bool, len(neighbors[v]))
+		for _, u := range neighbors[v] {
+			if c, ok := colors[u]; ok {
+				taken[c] = true
+			}
+		}
+		c := 0
+		for taken[c] {
+			c++
+		}
+		colors[v] = c
+	}
+	return colors
+}
+
+// smallestLastOrder removes vertices of the smallest degree one by one and returns them reversed
+func smallestLastOrder(gr *graph.Graph, neighbors map[graph.TKey][]graph.TKey) []graph.TKey {
+	keys := getSortedKeys(gr.Nodes)
+	degree := make(map[graph.TKey]int, len(keys))
+	for _, key := range keys {
+		degree[key] = len(neighbors[key])
+	}
+
+	removed := make(map[graph.TKey]bool, len(keys))
+	order := make([]graph.TKey, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		var next graph.TKey
+		found := false
+		for _, key := range keys {
+			if !removed[key] && (!found || degree[key] < degree[next]) {
+				next, found = key, true
+			}
+		}
+
+		removed[next] = true
+		order[i] = next
+		for _, u := range neighbors[next] {
+			degree[u]--
+		}
+	}
+	return order
+}
+
+// colorDSatur colors the most saturated vertex first
+func colorDSatur(gr *graph.Graph, neighbors map[graph.TKey][]graph.TKey) map[graph.TKey]int {
+	keys := getSortedKeys(gr.Nodes)
+	colors := make(map[graph.TKey]int, len(keys))
+	neighborColors := make(map[graph.TKey]map[int]bool, len(keys))
+	for _, key := range keys {
+		neighborColors[key] = make(map[int]bool)
+	}
+
+	for range keys {
+		var next graph.TKey
+		found := false
+		for _, key := range keys {
+			if _, done := colors[key]; done {
+				continue
+			}
+			if !found || len(neighborColors[key]) > len(neighborColors[next]) ||
+				(len(neighborColors[key]) == len(neighborColors[next]) && len(neighbors[key]) > len(neighbors[next])) {
+				next, found = key, true
+			}
+		}
+
+		c := 0
+		for neighborColors[next][c] {
+			c++
+		}
+		colors[next] = c
+		for _, u := range neighbors[next] {
+			neighborColors[u][c] = true
+		}
+	}
+	return colors
+}
+
+// greedyClique grows a clique from every vertex taking neighbors of highest degree, returns the largest
+func greedyClique(gr *graph.Graph, neighbors map[graph.TKey][]graph.TKey) []graph.TKey {
+	best := []graph.TKey{}
+	for _, start := range getSortedKeys(gr.Nodes) {
+		candidates := append([]graph.TKey{}, neighbors[start]...)
+		sort.SliceStable(candidates, func(i, j int) bool { return len(neighbors[candidates[i]]) > len(neighbors[candidates[j]]) })
+
+		clique := []graph.TKey{start}
+		for _, v := range candidates {
+			adjacentToAll := true
+			for _, u := range clique {
+				if !sortedContains(neighbors[v], u) {
+					adjacentToAll = false
+					break
+				}
+			}
+			if adjacentToAll {
+				clique = append(clique, v)
+			}
+		}
+
+		if len(clique) > len(best) {
+			best = clique
+		}
+	}
+	return best
+}
+
+// sortedContains checks if sorted keys contain key
+func sortedContains(keys []graph.TKey, key graph.TKey) bool {
+	i := sort.Search(len(keys), func(i int) bool { return keys[i] >= key })
+	return i < len(keys) && keys[i] == key
+}
+
+// countColors returns the number of colors, which is the largest color + 1
+func countColors(colors map[graph.TKey]int) int {
+	count := 0
+	for _, c := range colors {
+		count = max(count, c+1)
+	}
+	return count
+}
+
+// groupByColor lists sorted keys of every color
+func groupByColor(colors map[graph.TKey]int, numColors int) [][]graph.TKey {
+	classes := make([][]graph.TKey, numColors)
+	for _, key := range getSortedMapKeys(colors) {
+		classes[colors[key]] = append(classes[colors[key]], key)
+	}
+	return classes
+}
+
+// makeColoringResult fills colors, their number and classes
+func makeColoringResult(colors map[graph.TKey]int, algorithm string) *ColoringResult {
+	numColors := countColors(colors)
+	return &ColoringResult{
+		Colors:    colors,
+		NumColors: numColors,
+		Classes:   groupByColor(colors, numColors),
+		Algorithm: algorithm,
+	}
+}
+
+// colorSwatch returns a colored square for TUI, palette colors only
+func colorSwatch(color int) string {
+	if value := graph.DOTColor(color); strings.HasPrefix(value, "#") {
+		return fmt.Sprintf("[%s]■[-]", value)
+	}
+	return " "
+}
+
+// FormatColoringResult creates a formatted string representation
+func (result *ColoringResult) FormatColoringResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("VERTEX COLORING (%s)\n\n", result.Algorithm))
+	sb.WriteString(fmt.Sprintf("Colors used: %d\n", result.NumColors))
+	sb.WriteString(fmt.Sprintf("Largest clique found: %d\n", result.LowerBound))
+	if result.IsExact {
+		sb.WriteString(fmt.Sprintf("Chromatic number: %d\n\n", result.NumColors))
+	} else {
+		sb.WriteString(fmt.Sprintf("Chromatic number: between %d and %d\n\n", result.LowerBound, result.NumColors))
+	}
+
+	sb.WriteString("COLOR CLASSES:\n")
+	for c, class := range result.Classes {
+		sb.WriteString(fmt.Sprintf("%s Color %-3d (%d): %s\n", colorSwatch(c), c, len(class), formatKeyList(class)))
+	}
+
+	sb.WriteString("\nNODE COLORS:\n")
+	sb.WriteString(fmt.Sprintf("%-20s %-8s\n", "Node", "Color"))
+	sb.WriteString(strings.Repeat("─", 30) + "\n")
+	for _, key := range getSortedMapKeys(result.Colors) {
+		sb.WriteString(fmt.Sprintf("%-20s %s %d\n", formatNodeName(gr, key), colorSwatch(result.Colors[key]), result.Colors[key]))
+	}
+
+	return sb.String()
+}
+
+// FormatEdgeColoringResult creates a formatted string representation
+func (result *EdgeColoringResult) FormatEdgeColoringResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("EDGE COLORING (%s)\n\n", result.Algorithm))
+	sb.WriteString(fmt.Sprintf("Maximum degree Δ: %d\n", result.MaxDegree))
+	sb.WriteString(fmt.Sprintf("Colors used: %d\n\n", result.NumColors))
+
+	sb.WriteString("COLOR CLASSES (matchings):\n")
+	for c, class := range result.Classes {
+		sb.WriteString(fmt.Sprintf("%s Color %-3d (%d): edges %s\n", colorSwatch(c), c, len(class), formatKeyList(class)))
+	}
+
+	sb.WriteString("\nEDGE COLORS:\n")
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-8s\n", "Key", "From", "To", "Color"))
+	sb.WriteString(strings.Repeat("─", 34) + "\n")
+	for _, key := range getSortedMapKeys(result.Colors) {
+		edge := gr.Edges[key]
+		sb.WriteString(fmt.Sprintf("%-8d %-8d %-8d %s %d\n", key, edge.Source, edge.Destination, colorSwatch(result.Colors[key]), result.Colors[key]))
+	}
+
+	return sb.String()
+}
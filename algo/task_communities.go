@@ -0,0 +1,474 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find communities of undirected graph
+ *
+ * Modularity - fraction of edge weight inside communities minus the fraction
+ * expected if edges were rewired at random keeping degrees:
+ *   Q = Σ over communities (L_c / m - γ * (d_c / 2m)^2),
+ * where L_c is weight inside community, d_c is total degree of its nodes, m is
+ * total weight and γ is resolution (bigger γ gives smaller communities).
+ *
+ * Louvain Algorithm - two phases repeated while something changes:
+ *   1. Every node moves to the neighboring community with the largest
+ *      modularity gain, until no move helps
+ *   2. Communities are merged into single nodes (inner edges become loops)
+ * Each level is O(E) per pass, and there are usually few levels.
+ *
+ * Label Propagation - every node takes the label most common (by weight)
+ * among its neighbors, until labels stop changing. Nearly linear, but gives
+ * different answers for different orders.
+ *
+ * Both algorithms visit nodes in random order, so the seed option makes runs
+ * reproducible. Communities are numbered from 1 by their smallest node key.
+ * Quotient graph has a node per community and an edge per pair of adjacent
+ * communities, weighted by the total weight between them; weight inside a
+ * community is a loop.
+ */
+
+// CommunityOptions configures community detection
+type CommunityOptions struct {
+	Weighted      bool    // Use edge weights, otherwise every edge weighs 1
+	Resolution    float64 // Modularity resolution γ, 1 is the classic modularity
+	Seed          uint64  // Seed of random node order
+	MaxIterations int     // Label propagation rounds limit
+}
+
+func WithCommunityWeighted(weighted bool) graph.Option[CommunityOptions] {
+	return func(opts *CommunityOptions) {
+		opts.Weighted = weighted
+	}
+}
+
+func WithResolution(resolution float64) graph.Option[CommunityOptions] {
+	return func(opts *CommunityOptions) {
+		opts.Resolution = resolution
+	}
+}
+
+func WithCommunitySeed(seed uint64) graph.Option[CommunityOptions] {
+	return func(opts *CommunityOptions) {
+		opts.Seed = seed
+	}
+}
+
+func WithCommunityMaxIterations(iterations int) graph.Option[CommunityOptions] {
+	return func(opts *CommunityOptions) {
+		opts.MaxIterations = iterations
+	}
+}
+
+// CommunityResult represents partition of nodes into communities
+type CommunityResult struct {
+	Membership  map[graph.TKey]int // Community of every node, numbered from 1
+	Communities [][]graph.TKey     // Nodes of every community, sorted; community i is Communities[i-1]
+	Modularity  float64            // Modularity of the partition
+	Iterations  int                // Louvain levels or label propagation rounds
+	Weighted    bool               // Edge weights were used, otherwise every edge weighed 1
+	Algorithm   string             // Algorithm used
+	Message     string             // Status message
+}
+
+// communityGraph is weighted undirected graph on indices, loops kept apart
+type communityGraph struct {
+	adj    []map[int]float64 // Weight to every other neighbor
+	loops  []float64         // Weight of loops of every node
+	degree []float64         // Weighted degree, a loop counts twice
+	total  float64           // Sum of degrees, that is 2m
+}
+
+// parseCommunityOptions applies options over defaults
+func parseCommunityOptions(options []graph.Option[CommunityOptions]) CommunityOptions {
+	opts := CommunityOptions{Weighted: true, Resolution: 1, Seed: 1, MaxIterations: 100}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+// buildCommunityGraph converts graph to indices, summing parallel edges
+func buildCommunityGraph(gr *graph.Graph, keys []graph.TKey, weighted bool) (*communityGraph, error) {
+	if gr.Options.IsDirected {
+		return nil, graph.ThrowGraphDirected()
+	}
+
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	cg := newCommunityGraph(len(keys))
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		weight := communityEdgeWeight(edge, weighted)
+		if weight < 0 {
+			return nil, fmt.Errorf("community detection needs non-negative weights, edge %d has weight %d", key, edge.Weight)
+		}
+		cg.addEdge(index[edge.Source], index[edge.Destination], float64(weight))
+	}
+
+	if weighted && len(gr.Edges) > 0 && cg.total == 0 {
+		return nil, fmt.Errorf("all edge weights are zero, use unweighted mode")
+	}
+	return cg, nil
+}
+
+// communityEdgeWeight is weight of edge in community detection, 1 in unweighted mode
+func communityEdgeWeight(edge *graph.Edge, weighted bool) graph.TWeight {
+	if !weighted {
+		return 1
+	}
+	return edge.Weight
+}
+
+func newCommunityGraph(n int) *communityGraph {
+	cg := &communityGraph{
+		adj:    make([]map[int]float64, n),
+		loops:  make([]float64, n),
+		degree: make([]float64, n),
+	}
+	for i := range cg.adj {
+		cg.adj[i] = make(map[int]float64)
+	}
+	return cg
+}
+
+// addEdge adds weight between u and v, or a loop when they are equal
+func (cg *communityGraph) addEdge(u, v int, weight float64) {
+	if u == v {
+		cg.loops[u] += weight
+	} else {
+		cg.adj[u][v] += weight
+		cg.adj[v][u] += weight
+	}
+	cg.degree[u] += weight
+	cg.degree[v] += weight
+	cg.total += 2 * weight
+}
+
+// FindCommunitiesLouvain finds communities by Louvain modularity optimization
+func FindCommunitiesLouvain(gr *graph.Graph, options ...graph.Option[CommunityOptions]) (*CommunityResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	opts := parseCommunityOptions(options)
+
+	keys := getSortedKeys(gr.Nodes)
+	cg, err := buildCommunityGraph(gr, keys, opts.Weighted)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	membership := make([]int, len(keys)) // Community of every original node on the current level
+	for i := range membership {
+		membership[i] = i
+	}
+
+	levels := 0
+	for cg.total > 0 {
+		community, moved := louvainMoveNodes(cg, opts.Resolution, rng)
+		if !moved {
+			break
+		}
+		levels++
+
+		// Renumber communities densely and merge them into nodes of the next level
+		renumber := make(map[int]int)
+		for _, c := range community {
+			if _, ok := renumber[c]; !ok {
+				renumber[c] = len(renumber)
+			}
+		}
+		next := newCommunityGraph(len(renumber))
+		for u := range cg.adj {
+			cu := renumber[community[u]]
+			if cg.loops[u] > 0 {
+				next.addEdge(cu, cu, cg.loops[u])
+			}
+			for v, weight := range cg.adj[u] {
+				if u < v {
+					next.addEdge(cu, renumber[community[v]], weight)
+				}
+			}
+		}
+		for i := range membership {
+			membership[i] = renumber[community[membership[i]]]
+		}
+		cg = next
+	}
+
+	return makeCommunityResult(gr, keys, membership, levels, "Louvain", opts)
+}
+
+// louvainMoveNodes is the first Louvain phase: returns community of every node and if any node moved
+func louvainMoveNodes(cg *communityGraph, resolution float64, rng *rand.Rand) ([]int, bool) {
+	n := len(cg.adj)
+	community := make([]int, n)
+	totalDegree := make([]float64, n) // Sum of degrees of community members
+	for i := range community {
+		community[i] = i
+		totalDegree[i] = cg.degree[i]
+	}
+
+	order := rng.Perm(n)
+	movedAny := false
+	for improved := true; improved; {
+		improved = false
+		for _, u := range order {
+			// Weight from u to every neighboring community, in a fixed order
+			links := make(map[int]float64)
+			candidates := []int{}
+			for _, v := range slices.Sorted(maps.Keys(cg.adj[u])) {
+				c := community[v]
+				if _, seen := links[c]; !seen {
+					candidates = append(candidates, c)
+				}
+				links[c] += cg.adj[u][v]
+			}
+
+			current := community[u]
+			totalDegree[current] -= cg.degree[u]
+
+			// Gain of joining c, up to a positive factor: k_u,in(c) - γ * Σtot(c) * k_u / 2m
+			gain := func(c int) float64 {
+				return links[c] - resolution*totalDegree[c]*cg.degree[u]/cg.total
+			}
+			best, bestGain := current, gain(current)
+			for _, c := range candidates {
+				if g := gain(c); g > bestGain+1e-12 {
+					best, bestGain = c, g
+				}
+			}
+
+			community[u] = best
+			totalDegree[best] += cg.degree[u]
+			if best != current {
+				improved = true
+				movedAny = true
+			}
+		}
+	}
+	return community, movedAny
+}
+
+// FindCommunitiesLabelPropagation finds communities by asynchronous label propagation
+func FindCommunitiesLabelPropagation(gr *graph.Graph, options ...graph.Option[CommunityOptions]) (*CommunityResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	opts := parseCommunityOptions(options)
+
+	keys := getSortedKeys(gr.Nodes)
+	cg, err := buildCommunityGraph(gr, keys, opts.Weighted)
+	if err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed))
+	label := make([]int, len(keys))
+	for i := range label {
+		label[i] = i
+	}
+
+	rounds := 0
+	for changed := true; changed && rounds < opts.MaxIterations; {
+		changed = false
+		rounds++
+
+		for _, u := range rng.Perm(len(keys)) {
+			weights := make(map[int]float64)
+			for v, weight := range cg.adj[u] {
+				weights[label[v]] += weight
+			}
+			if len(weights) == 0 {
+				continue
+			}
+
+			// Heaviest labels, current one wins ties so that labels settle
+			heaviest := 0.0
+			for _, weight := range weights {
+				heaviest = max(heaviest, weight)
+			}
+			if weights[label[u]] == heaviest {
+				continue
+			}
+			best := []int{}
+			for _, l := range slices.Sorted(maps.Keys(weights)) {
+				if weights[l] == heaviest {
+					best = append(best, l)
+				}
+			}
+
+			label[u] = best[rng.IntN(len(best))]
+			changed = true
+		}
+	}
+
+	return makeCommunityResult(gr, keys, label, rounds, "Label propagation", opts)
+}
+
+// Modularity computes modularity of partition given by community of every node
+func Modularity(gr *graph.Graph, membership map[graph.TKey]int, options ...graph.Option[CommunityOptions]) (float64, error) {
+	if gr.Nodes == nil {
+		return 0, graph.ThrowNodesListIsNil()
+	}
+	opts := parseCommunityOptions(options)
+
+	keys := getSortedKeys(gr.Nodes)
+	cg, err := buildCommunityGraph(gr, keys, opts.Weighted)
+	if err != nil {
+		return 0, err
+	}
+
+	community := make([]int, len(keys))
+	for i, key := range keys {
+		c, ok := membership[key]
+		if !ok {
+			return 0, fmt.Errorf("node %d has no community", key)
+		}
+		community[i] = c
+	}
+	return cg.modularity(community, opts.Resolution), nil
+}
+
+// modularity computes Q of the partition, 0 for graph without edges
+func (cg *communityGraph) modularity(community []int, resolution float64) float64 {
+	if cg.total == 0 {
+		return 0
+	}
+
+	inside := make(map[int]float64)
+	degree := make(map[int]float64)
+	for u := range cg.adj {
+		c := community[u]
+		degree[c] += cg.degree[u]
+		inside[c] += 2 * cg.loops[u]
+		for _, v := range slices.Sorted(maps.Keys(cg.adj[u])) {
+			if community[v] == c {
+				inside[c] += cg.adj[u][v] // Counted from both ends, as the total
+			}
+		}
+	}
+
+	// Fixed order of summation, so equal partitions give equal values
+	q := 0.0
+	for _, c := range slices.Sorted(maps.Keys(degree)) {
+		d := degree[c]
+		q += inside[c]/cg.total - resolution*(d/cg.total)*(d/cg.total)
+	}
+	return q
+}
+
+// makeCommunityResult numbers communities from 1 by their smallest key and computes modularity
+func makeCommunityResult(gr *graph.Graph, keys []graph.TKey, community []int, iterations int, algorithm string, opts CommunityOptions) (*CommunityResult, error) {
+	result := &CommunityResult{
+		Membership:  make(map[graph.TKey]int, len(keys)),
+		Communities: [][]graph.TKey{},
+		Iterations:  iterations,
+		Weighted:    opts.Weighted,
+		Algorithm:   algorithm,
+	}
+
+	// Keys are sorted, so the first member of a community is its smallest one
+	number := make(map[int]int)
+	for i, key := range keys {
+		if _, ok := number[community[i]]; !ok {
+			number[community[i]] = len(number) + 1
+			result.Communities = append(result.Communities, []graph.TKey{})
+		}
+		c := number[community[i]]
+		result.Membership[key] = c
+		result.Communities[c-1] = append(result.Communities[c-1], key)
+	}
+
+	modularity, err := Modularity(gr, result.Membership, WithCommunityWeighted(opts.Weighted), WithResolution(opts.Resolution))
+	if err != nil {
+		return nil, err
+	}
+	result.Modularity = modularity
+	result.Message = fmt.Sprintf("%s found %d communities, modularity %.4f", algorithm, len(result.Communities), modularity)
+
+	return result, nil
+}
+
+// QuotientGraph builds graph with a node per community. Edge weights are total
+// weights between communities (edge counts in unweighted mode), weight inside a
+// community becomes a loop
+func (result *CommunityResult) QuotientGraph(gr *graph.Graph) (*graph.Graph, error) {
+	quotient := graph.MakeGraph(graph.WithGraphDirected(false))
+	for i, members := range result.Communities {
+		label := fmt.Sprintf("community of %d", len(members))
+		if err := quotient.AddNode(graph.MakeNode(graph.TKey(i+1), graph.WithNodeLabel(label))); err != nil {
+			return nil, err
+		}
+	}
+
+	weights := make(map[[2]graph.TKey]graph.TWeight)
+	for _, edge := range gr.Edges {
+		cu, okU := result.Membership[edge.Source]
+		cv, okV := result.Membership[edge.Destination]
+		if !okU || !okV {
+			return nil, fmt.Errorf("edge %d joins nodes without community", edge.Key)
+		}
+		pair := [2]graph.TKey{graph.TKey(min(cu, cv)), graph.TKey(max(cu, cv))}
+		weights[pair] += communityEdgeWeight(edge, result.Weighted)
+	}
+
+	pairs := make([][2]graph.TKey, 0, len(weights))
+	for pair := range weights {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0] || pairs[i][0] == pairs[j][0] && pairs[i][1] < pairs[j][1]
+	})
+	for i, pair := range pairs {
+		edge := graph.MakeEdge(graph.TKey(i+1), pair[0], pair[1], graph.WithEdgeWeight(weights[pair]))
+		if err := quotient.AddEdge(edge); err != nil {
+			return nil, err
+		}
+	}
+
+	return quotient, nil
+}
+
+// FormatCommunityResult creates a formatted string representation
+func (result *CommunityResult) FormatCommunityResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("COMMUNITIES (%s)\n\n", result.Algorithm))
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Communities: %d\n", len(result.Communities)))
+	sb.WriteString(fmt.Sprintf("Modularity: %.6f\n", result.Modularity))
+	sb.WriteString(fmt.Sprintf("Iterations: %d\n\n", result.Iterations))
+
+	// Largest communities first
+	order := make([]int, len(result.Communities))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(result.Communities[b]) - len(result.Communities[a]) })
+
+	sb.WriteString(fmt.Sprintf("%-12s %-8s %s\n", "Community", "Size", "Nodes"))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for _, i := range order {
+		sb.WriteString(fmt.Sprintf("%-12d %-8d %s\n", i+1, len(result.Communities[i]), formatKeyList(result.Communities[i])))
+	}
+
+	return sb.String()
+}
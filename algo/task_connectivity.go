@@ -0,0 +1,257 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find edge and vertex connectivity, and disjoint paths between two nodes
+ *
+ * Menger's theorem: the largest number of edge-disjoint s → t paths equals
+ * the smallest number of edges separating t from s. The same holds for
+ * internally vertex-disjoint paths and vertices. Both numbers are max flows:
+ * with capacity 1 on every edge for edges, and with additionally capacity 1
+ * on every node except s and t for vertices.
+ *
+ * Edge connectivity of graph - the smallest λ(v0, v) and λ(v, v0) over all v
+ * for any fixed v0, because v0 is on one side of any cut. V - 1 max flows.
+ *
+ * Vertex connectivity of graph (Even's algorithm) - the smallest κ(vi, vj) of
+ * non-adjacent pairs, where only the first κ + 1 vertices vi have to be tried:
+ * one of them is out of the minimum separator. Complete graph has κ = V - 1.
+ */
+
+// ConnectivityResult represents edge and vertex connectivity of the graph
+type ConnectivityResult struct {
+	EdgeConnectivity   int  // Fewest edges whose removal disconnects the graph
+	VertexConnectivity int  // Fewest vertices whose removal disconnects the graph
+	IsComplete         bool // Complete graph cannot be disconnected by removing vertices
+	Message            string
+}
+
+// DisjointPathsResult represents maximum set of disjoint paths between two nodes
+type DisjointPathsResult struct {
+	Source         graph.TKey   // Start of all paths
+	Sink           graph.TKey   // End of all paths
+	VertexDisjoint bool         // Paths share no inner vertices, not only edges
+	Paths          []FlowPath   // Disjoint paths, each with amount 1
+	Separator      []graph.TKey // Minimum set of edge keys (or node keys) separating sink from source
+	Message        string       // Status message
+}
+
+// FindConnectivity finds edge and vertex connectivity of the graph
+func FindConnectivity(gr *graph.Graph) (*ConnectivityResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	if len(keys) < 2 {
+		return &ConnectivityResult{Message: "Graph has less than 2 nodes, connectivity is 0"}, nil
+	}
+
+	// Step 1: Edge connectivity through cuts around the first vertex
+	edgeConnectivity := len(gr.Edges)
+	for _, key := range keys[1:] {
+		value, err := EdgeConnectivityBetween(gr, keys[0], key)
+		if err != nil {
+			return nil, err
+		}
+		edgeConnectivity = min(edgeConnectivity, value)
+
+		if gr.Options.IsDirected {
+			if value, err = EdgeConnectivityBetween(gr, key, keys[0]); err != nil {
+				return nil, err
+			}
+			edgeConnectivity = min(edgeConnectivity, value)
+		}
+	}
+
+	// Step 2: Vertex connectivity by Even's algorithm
+	adjacent := make(map[[2]graph.TKey]bool)
+	for _, edge := range gr.Edges {
+		adjacent[[2]graph.TKey{edge.Source, edge.Destination}] = true
+		if !gr.Options.IsDirected {
+			adjacent[[2]graph.TKey{edge.Destination, edge.Source}] = true
+		}
+	}
+
+	vertexConnectivity := len(keys) - 1
+	isComplete := true
+	for i := 0; i < len(keys) && i <= vertexConnectivity; i++ {
+		for j := i + 1; j < len(keys); j++ {
+			for _, pair := range [][2]graph.TKey{{keys[i], keys[j]}, {keys[j], keys[i]}} {
+				if adjacent[pair] {
+					continue
+				}
+				isComplete = false
+
+				value, err := VertexConnectivityBetween(gr, pair[0], pair[1])
+				if err != nil {
+					return nil, err
+				}
+				vertexConnectivity = min(vertexConnectivity, value)
+
+				if !gr.Options.IsDirected {
+					break // Undirected connectivity is symmetric
+				}
+			}
+		}
+	}
+
+	return &ConnectivityResult{
+		EdgeConnectivity:   edgeConnectivity,
+		VertexConnectivity: vertexConnectivity,
+		IsComplete:         isComplete,
+		Message: fmt.Sprintf("Edge connectivity is %d, vertex connectivity is %d",
+			edgeConnectivity, vertexConnectivity),
+	}, nil
+}
+
+// EdgeConnectivityBetween returns the largest number of edge-disjoint paths from s to t
+func EdgeConnectivityBetween(gr *graph.Graph, s, t graph.TKey) (int, error) {
+	result, err := FindMaxFlow(gr, s, t, WithUnitCapacities(true))
+	if err != nil {
+		return 0, err
+	}
+	return int(result.MaxFlowValue), nil
+}
+
+// VertexConnectivityBetween returns the largest number of internally vertex-disjoint
+// paths from s to t. For adjacent nodes the direct edge counts as one of them
+func VertexConnectivityBetween(gr *graph.Graph, s, t graph.TKey) (int, error) {
+	result, err := FindDisjointPaths(gr, s, t, true)
+	if err != nil {
+		return 0, err
+	}
+	return len(result.Paths), nil
+}
+
+// FindDisjointPaths finds maximum set of edge-disjoint or vertex-disjoint paths from s to t
+func FindDisjointPaths(gr *graph.Graph, s, t graph.TKey, vertexDisjoint bool) (*DisjointPathsResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	options := []graph.Option[MaxFlowOptions]{WithUnitCapacities(true)}
+	if vertexDisjoint {
+		// Every inner vertex may be used by one path only
+		capacities := make(map[graph.TKey]graph.TWeight, len(gr.Nodes))
+		for key := range gr.Nodes {
+			if key != s && key != t {
+				capacities[key] = 1
+			}
+		}
+		options = append(options, WithNodeCapacities(capacities))
+	}
+
+	flow, err := FindMaxFlow(gr, s, t, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DisjointPathsResult{
+		Source:         s,
+		Sink:           t,
+		VertexDisjoint: vertexDisjoint,
+		Paths:          []FlowPath{},
+		Separator:      []graph.TKey{},
+	}
+
+	// Parallel edges s → t are different edge-disjoint paths, but the same vertex-disjoint one
+	hasDirectPath := false
+	for _, path := range flow.Decomposition.Paths {
+		if vertexDisjoint && len(path.Edges) == 1 {
+			if hasDirectPath {
+				continue
+			}
+			hasDirectPath = true
+		}
+		result.Paths = append(result.Paths, path)
+	}
+
+	if vertexDisjoint {
+		// Unit edges may be cut instead of nodes, removing their inner end does the same
+		separator := make(map[graph.TKey]bool, len(flow.CutNodes))
+		for _, key := range flow.CutNodes {
+			separator[key] = true
+		}
+		for _, edge := range flow.CutEdges {
+			switch {
+			case edge.Source != s:
+				separator[edge.Source] = true
+			case edge.Destination != t:
+				separator[edge.Destination] = true
+			}
+		}
+		result.Separator = getSortedMapKeys(separator)
+	} else {
+		for _, edge := range flow.CutEdges {
+			result.Separator = append(result.Separator, edge.EdgeKey)
+		}
+	}
+
+	kind := "edge"
+	if vertexDisjoint {
+		kind = "vertex"
+	}
+	result.Message = fmt.Sprintf("Found %d %s-disjoint path(s) from %d to %d", len(result.Paths), kind, s, t)
+	if vertexDisjoint && hasDirectPath {
+		result.Message += ", nodes are adjacent and cannot be separated"
+	}
+
+	return result, nil
+}
+
+// FormatConnectivityResult creates a formatted string representation
+func (result *ConnectivityResult) FormatConnectivityResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("GRAPH CONNECTIVITY\n\n")
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Total edges: %d\n\n", len(gr.Edges)))
+	sb.WriteString(fmt.Sprintf("Edge connectivity λ: %d\n", result.EdgeConnectivity))
+	sb.WriteString(fmt.Sprintf("Vertex connectivity κ: %d\n", result.VertexConnectivity))
+	if result.IsComplete && len(gr.Nodes) > 1 {
+		sb.WriteString("Graph is complete, κ = V - 1 by convention\n")
+	}
+	sb.WriteString(fmt.Sprintf("\nGraph stays connected after removing any %d edge(s) or %d vertex(es)\n",
+		max(result.EdgeConnectivity-1, 0), max(result.VertexConnectivity-1, 0)))
+
+	return sb.String()
+}
+
+// FormatDisjointPathsResult creates a formatted string representation
+func (result *DisjointPathsResult) FormatDisjointPathsResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	kind := "EDGE"
+	if result.VertexDisjoint {
+		kind = "VERTEX"
+	}
+
+	sb.WriteString(fmt.Sprintf("%s-DISJOINT PATHS (Menger)\n\n", kind))
+	sb.WriteString(fmt.Sprintf("Source: %s\n", formatNodeName(gr, result.Source)))
+	sb.WriteString(fmt.Sprintf("Sink: %s\n", formatNodeName(gr, result.Sink)))
+	sb.WriteString(fmt.Sprintf("Number of paths: %d\n\n", len(result.Paths)))
+
+	sb.WriteString("PATHS:\n")
+	for i, path := range result.Paths {
+		sb.WriteString(fmt.Sprintf("%d. %s  (edges %s)\n", i+1, formatTrail(path.Vertices), formatKeyList(path.Edges)))
+	}
+
+	if result.VertexDisjoint {
+		sb.WriteString(fmt.Sprintf("\nMINIMUM SEPARATING VERTICES (%d): %s\n", len(result.Separator), formatKeyList(result.Separator)))
+	} else {
+		sb.WriteString(fmt.Sprintf("\nMINIMUM SEPARATING EDGES (%d): %s\n", len(result.Separator), formatKeyList(result.Separator)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,542 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"container/heap"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Answer many point-to-point shortest path queries on the same graph
+ *
+ * Bidirectional Dijkstra - two searches at once: forward from source and
+ * backward (over reversed arcs) from target, always advancing the smaller
+ * frontier. Each time an arc reaches a node seen by the other side, the path
+ * through it is a candidate. The search stops when the two smallest
+ * distances in the queues sum to at least the best candidate. On road-like
+ * graphs it settles about half the nodes of plain Dijkstra.
+ *
+ * Contraction Hierarchies - preprocessing contracts nodes one by one, from
+ * the least important. Contracting v removes it and adds shortcut u → w for
+ * every pair of arcs u → v → w, unless a witness path u ⇝ w not through v is
+ * as short (found by a small local Dijkstra). Importance is edge difference:
+ * shortcuts added minus arcs removed, plus already contracted neighbors, kept
+ * in a lazy priority queue. Every arc ends up going up or down in the order.
+ * Query runs bidirectional Dijkstra where both sides only go up: forward over
+ * upward arcs from source, backward over downward arcs into target. It settles
+ * a few hundred nodes even on big road graphs. Shortcuts remember the two arcs
+ * they replace, so paths are unpacked back into original edges.
+ *
+ * Index belongs to the graph it was built for and remembers its revision, so
+ * queries fail once graph is mutated (see graph.Revision). Index can be saved
+ * as JSON with graph fingerprint, and loaded only for a graph with the same
+ * fingerprint.
+ */
+
+// chWitnessLimit bounds nodes settled by one witness search, more shortcuts but faster preprocessing
+const chWitnessLimit = 200
+
+// PointToPointResult represents shortest path between two nodes found by a point-to-point query
+type PointToPointResult struct {
+	Source    graph.TKey   // Start node
+	Target    graph.TKey   // End node
+	Reachable bool         // Target is reachable from source
+	Path      WeightedPath // Shortest path, when reachable
+	Settled   int          // Nodes settled by both searches, shows work done by the query
+	Algorithm string       // Algorithm used
+	Message   string       // Status message
+}
+
+// chArc is an arc of contraction hierarchy: original edge or shortcut of two arcs
+type chArc struct {
+	From   int        `json:"from"`
+	To     int        `json:"to"`
+	Weight int64      `json:"weight"`
+	Edge   graph.TKey `json:"edge"`   // Original edge, for arcs that are not shortcuts
+	First  int        `json:"first"`  // Arc from From to the contracted node, -1 for original edge
+	Second int        `json:"second"` // Arc from the contracted node to To, -1 for original edge
+}
+
+// ContractionHierarchy is a reusable index for fast shortest path queries on one graph
+type ContractionHierarchy struct {
+	graph       *graph.Graph
+	revision    uint64
+	fingerprint uint64
+	keys        []graph.TKey
+	index       map[graph.TKey]int
+	rank        []int   // Position of every node in contraction order
+	arcs        []chArc // Original arcs and shortcuts
+	up          [][]int // Arcs to higher ranked nodes, for forward search
+	down        [][]int // Arcs from higher ranked nodes, for backward search
+}
+
+// chFile is serialized form of contraction hierarchy
+type chFile struct {
+	Fingerprint uint64       `json:"fingerprint"`
+	Keys        []graph.TKey `json:"keys"`
+	Rank        []int        `json:"rank"`
+	Arcs        []chArc      `json:"arcs"`
+}
+
+// BidirectionalDijkstra finds shortest path from source to target searching from both ends
+func BidirectionalDijkstra(gr *graph.Graph, source, target graph.TKey) (*PointToPointResult, error) {
+	if err := validatePathEnds(gr, source, target); err != nil {
+		return nil, err
+	}
+
+	forwardArcs := buildArcLists(gr)
+	backwardArcs := make(map[graph.TKey][]weightedArc)
+	for from, arcs := range forwardArcs {
+		for _, arc := range arcs {
+			backwardArcs[arc.to] = append(backwardArcs[arc.to], weightedArc{to: from, key: arc.key, weight: arc.weight})
+		}
+	}
+
+	// Backward tree keeps steps towards target: prev[v].from is the next node on the path
+	forward := &shortestPathTree{source: source, dist: map[graph.TKey]int64{source: 0}, prev: make(map[graph.TKey]pathStep)}
+	backward := &shortestPathTree{source: target, dist: map[graph.TKey]int64{target: 0}, prev: make(map[graph.TKey]pathStep)}
+	forwardQueue := &distanceHeap{{vertex: source, dist: 0}}
+	backwardQueue := &distanceHeap{{vertex: target, dist: 0}}
+	settled := [2]map[graph.TKey]bool{make(map[graph.TKey]bool), make(map[graph.TKey]bool)}
+
+	best, meet := int64(math.MaxInt64), source
+	if source == target {
+		best = 0
+	}
+	for forwardQueue.Len() > 0 && backwardQueue.Len() > 0 {
+		if (*forwardQueue)[0].dist+(*backwardQueue)[0].dist >= best {
+			break
+		}
+
+		side, tree, other, queue, arcs := 0, forward, backward, forwardQueue, forwardArcs
+		if backwardQueue.Len() < forwardQueue.Len() {
+			side, tree, other, queue, arcs = 1, backward, forward, backwardQueue, backwardArcs
+		}
+
+		item := heap.Pop(queue).(distanceItem)
+		if settled[side][item.vertex] {
+			continue
+		}
+		settled[side][item.vertex] = true
+
+		for _, arc := range arcs[item.vertex] {
+			newDist := item.dist + arc.weight
+			if current, reached := tree.dist[arc.to]; !reached || newDist < current {
+				tree.dist[arc.to] = newDist
+				tree.prev[arc.to] = pathStep{from: item.vertex, edge: arc.key}
+				heap.Push(queue, distanceItem{vertex: arc.to, dist: newDist})
+			}
+			if otherDist, reached := other.dist[arc.to]; reached && tree.dist[arc.to]+otherDist < best {
+				best, meet = tree.dist[arc.to]+otherDist, arc.to
+			}
+		}
+	}
+
+	result := &PointToPointResult{
+		Source:    source,
+		Target:    target,
+		Settled:   len(settled[0]) + len(settled[1]),
+		Algorithm: "Bidirectional Dijkstra",
+	}
+	if best == math.MaxInt64 {
+		result.Message = fmt.Sprintf("Node %d is not reachable from %d", target, source)
+		return result, nil
+	}
+
+	vertices, edges := forward.pathTo(meet)
+	for current := meet; current != target; {
+		step := backward.prev[current]
+		vertices = append(vertices, step.from)
+		edges = append(edges, step.edge)
+		current = step.from
+	}
+	result.Reachable = true
+	result.Path = WeightedPath{Vertices: vertices, Edges: edges, Weight: best}
+	result.Message = fmt.Sprintf("Shortest path from %d to %d has weight %d, %d nodes settled", source, target, best, result.Settled)
+	return result, nil
+}
+
+// BuildContractionHierarchy preprocesses graph into contraction hierarchy index
+func BuildContractionHierarchy(gr *graph.Graph) (*ContractionHierarchy, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if err := checkNonNegativeWeights(gr); err != nil {
+		return nil, err
+	}
+
+	ch := &ContractionHierarchy{graph: gr, revision: gr.Revision(), fingerprint: gr.Fingerprint()}
+	ch.setKeys(getSortedKeys(gr.Nodes))
+	n := len(ch.keys)
+
+	// Remaining graph: arc ids by neighbor, only the lightest arc of every pair
+	out := make([]map[int]int, n)
+	in := make([]map[int]int, n)
+	for v := range n {
+		out[v] = make(map[int]int)
+		in[v] = make(map[int]int)
+	}
+	arcs := []chArc{}
+	addArc := func(arc chArc) {
+		if id, ok := out[arc.From][arc.To]; ok && arcs[id].Weight <= arc.Weight {
+			return
+		}
+		out[arc.From][arc.To] = len(arcs)
+		in[arc.To][arc.From] = len(arcs)
+		arcs = append(arcs, arc)
+	}
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		u, v := ch.index[edge.Source], ch.index[edge.Destination]
+		if u == v {
+			continue
+		}
+		addArc(chArc{From: u, To: v, Weight: int64(edge.Weight), Edge: key, First: -1, Second: -1})
+		if !gr.Options.IsDirected {
+			addArc(chArc{From: v, To: u, Weight: int64(edge.Weight), Edge: key, First: -1, Second: -1})
+		}
+	}
+
+	// witness finds distances from u avoiding v, up to limit weight
+	witness := func(u, v int, limit int64) map[int]int64 {
+		dist := map[int]int64{u: 0}
+		settled := make(map[int]bool)
+		pq := &distanceHeap{{vertex: graph.TKey(u), dist: 0}}
+		for pq.Len() > 0 && len(settled) < chWitnessLimit {
+			item := heap.Pop(pq).(distanceItem)
+			x := int(item.vertex)
+			if settled[x] {
+				continue
+			}
+			if item.dist > limit {
+				break
+			}
+			settled[x] = true
+			for y, id := range out[x] {
+				newDist := item.dist + arcs[id].Weight
+				if current, reached := dist[y]; y != v && (!reached || newDist < current) {
+					dist[y] = newDist
+					heap.Push(pq, distanceItem{vertex: graph.TKey(y), dist: newDist})
+				}
+			}
+		}
+		return dist
+	}
+
+	// shortcuts counts shortcuts needed to contract v, and adds them if asked
+	shortcuts := func(v int, add bool) int {
+		count := 0
+		successors := getSortedIntKeys(out[v])
+		for _, u := range getSortedIntKeys(in[v]) {
+			inArc := in[v][u]
+			limit := int64(0)
+			for _, w := range successors {
+				if w != u {
+					limit = max(limit, arcs[inArc].Weight+arcs[out[v][w]].Weight)
+				}
+			}
+			dist := witness(u, v, limit)
+			for _, w := range successors {
+				outArc := out[v][w]
+				via := arcs[inArc].Weight + arcs[outArc].Weight
+				if d, reached := dist[w]; w == u || reached && d <= via {
+					continue
+				}
+				count++
+				if add {
+					addArc(chArc{From: u, To: w, Weight: via, First: inArc, Second: outArc})
+				}
+			}
+		}
+		return count
+	}
+
+	deleted := make([]int, n) // Contracted neighbors of every node
+	priority := func(v int) int64 {
+		return int64(shortcuts(v, false) - len(in[v]) - len(out[v]) + deleted[v])
+	}
+
+	ch.rank = make([]int, n)
+	ch.up = make([][]int, n)
+	ch.down = make([][]int, n)
+	pq := &distanceHeap{}
+	for v := range n {
+		heap.Push(pq, distanceItem{vertex: graph.TKey(v), dist: priority(v)})
+	}
+	contracted := make([]bool, n)
+	for order := 0; pq.Len() > 0; {
+		v := int(heap.Pop(pq).(distanceItem).vertex)
+		if contracted[v] {
+			continue
+		}
+		// Lazy update: priority could grow since it was pushed
+		if current := priority(v); pq.Len() > 0 && current > (*pq)[0].dist {
+			heap.Push(pq, distanceItem{vertex: graph.TKey(v), dist: current})
+			continue
+		}
+
+		shortcuts(v, true)
+		ch.rank[v] = order
+		order++
+		contracted[v] = true
+		for w, id := range out[v] {
+			ch.up[v] = append(ch.up[v], id)
+			delete(in[w], v)
+			deleted[w]++
+		}
+		for u, id := range in[v] {
+			ch.down[v] = append(ch.down[v], id)
+			delete(out[u], v)
+			deleted[u]++
+		}
+		out[v], in[v] = nil, nil
+	}
+
+	// Keep only arcs of the hierarchy, replaced arcs are dropped
+	ch.arcs = nil
+	renumber := make(map[int]int)
+	for v := range n {
+		for _, list := range [][]int{ch.up[v], ch.down[v]} {
+			for _, id := range list {
+				renumber[id] = len(ch.arcs)
+				ch.arcs = append(ch.arcs, arcs[id])
+			}
+		}
+	}
+	for i := range ch.arcs {
+		if ch.arcs[i].First != -1 {
+			ch.arcs[i].First, ch.arcs[i].Second = renumber[ch.arcs[i].First], renumber[ch.arcs[i].Second]
+		}
+	}
+	ch.linkArcs()
+
+	return ch, nil
+}
+
+// getSortedIntKeys returns keys of int map in increasing order
+func getSortedIntKeys(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// setKeys fills node keys and their indices
+func (ch *ContractionHierarchy) setKeys(keys []graph.TKey) {
+	ch.keys = keys
+	ch.index = make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		ch.index[key] = i
+	}
+}
+
+// linkArcs sorts arcs into upward and downward lists by rank of their ends
+func (ch *ContractionHierarchy) linkArcs() {
+	ch.up = make([][]int, len(ch.keys))
+	ch.down = make([][]int, len(ch.keys))
+	for id, arc := range ch.arcs {
+		if ch.rank[arc.From] < ch.rank[arc.To] {
+			ch.up[arc.From] = append(ch.up[arc.From], id)
+		} else {
+			ch.down[arc.To] = append(ch.down[arc.To], id)
+		}
+	}
+}
+
+// Graph returns graph the index is bound to
+func (ch *ContractionHierarchy) Graph() *graph.Graph {
+	return ch.graph
+}
+
+// Valid checks that graph was not changed since index was built or loaded
+func (ch *ContractionHierarchy) Valid() bool {
+	return ch.graph.Revision() == ch.revision
+}
+
+// Shortcuts returns number of shortcuts added by preprocessing
+func (ch *ContractionHierarchy) Shortcuts() int {
+	count := 0
+	for _, arc := range ch.arcs {
+		if arc.First != -1 {
+			count++
+		}
+	}
+	return count
+}
+
+// Query finds shortest path from source to target using the index
+func (ch *ContractionHierarchy) Query(source, target graph.TKey) (*PointToPointResult, error) {
+	if !ch.Valid() {
+		return nil, fmt.Errorf("contraction hierarchy is outdated: graph was changed after it was built")
+	}
+	s, ok := ch.index[source]
+	if !ok {
+		return nil, fmt.Errorf("source node %d does not exist", source)
+	}
+	t, ok := ch.index[target]
+	if !ok {
+		return nil, fmt.Errorf("target node %d does not exist", target)
+	}
+
+	// Both searches go up the hierarchy; prev keeps the arc used to reach a node
+	dist := [2]map[int]int64{{s: 0}, {t: 0}}
+	prev := [2]map[int]int{{}, {}}
+	queues := [2]*distanceHeap{{{vertex: graph.TKey(s), dist: 0}}, {{vertex: graph.TKey(t), dist: 0}}}
+	settled := [2]map[int]bool{{}, {}}
+
+	best, meet := int64(math.MaxInt64), -1
+	for side := 0; queues[0].Len() > 0 || queues[1].Len() > 0; side = 1 - side {
+		queue := queues[side]
+		if queue.Len() == 0 {
+			continue
+		}
+		item := heap.Pop(queue).(distanceItem)
+		v := int(item.vertex)
+		if item.dist >= best {
+			*queue = (*queue)[:0] // Nothing shorter can be found on this side
+			continue
+		}
+		if settled[side][v] {
+			continue
+		}
+		settled[side][v] = true
+		if otherDist, reached := dist[1-side][v]; reached && item.dist+otherDist < best {
+			best, meet = item.dist+otherDist, v
+		}
+
+		lists := ch.up
+		if side == 1 {
+			lists = ch.down
+		}
+		for _, id := range lists[v] {
+			arc := ch.arcs[id]
+			next := arc.To
+			if side == 1 {
+				next = arc.From
+			}
+			newDist := item.dist + arc.Weight
+			if current, reached := dist[side][next]; !reached || newDist < current {
+				dist[side][next] = newDist
+				prev[side][next] = id
+				heap.Push(queue, distanceItem{vertex: graph.TKey(next), dist: newDist})
+			}
+		}
+	}
+
+	result := &PointToPointResult{
+		Source:    source,
+		Target:    target,
+		Settled:   len(settled[0]) + len(settled[1]),
+		Algorithm: "Contraction hierarchy",
+	}
+	if meet == -1 {
+		result.Message = fmt.Sprintf("Node %d is not reachable from %d", target, source)
+		return result, nil
+	}
+
+	// Hierarchy arcs from source to meeting node, then from meeting node to target
+	route := []int{}
+	for v := meet; v != s; v = ch.arcs[prev[0][v]].From {
+		route = append(route, prev[0][v])
+	}
+	slices.Reverse(route)
+	for v := meet; v != t; v = ch.arcs[prev[1][v]].To {
+		route = append(route, prev[1][v])
+	}
+
+	path := WeightedPath{Vertices: []graph.TKey{source}, Edges: []graph.TKey{}, Weight: best}
+	for _, id := range route {
+		for _, original := range ch.unpack(id) {
+			path.Vertices = append(path.Vertices, ch.keys[ch.arcs[original].To])
+			path.Edges = append(path.Edges, ch.arcs[original].Edge)
+		}
+	}
+	result.Reachable = true
+	result.Path = path
+	result.Message = fmt.Sprintf("Shortest path from %d to %d has weight %d, %d nodes settled", source, target, best, result.Settled)
+	return result, nil
+}
+
+// unpack replaces shortcut with original arcs it stands for
+func (ch *ContractionHierarchy) unpack(id int) []int {
+	arc := ch.arcs[id]
+	if arc.First == -1 {
+		return []int{id}
+	}
+	return append(ch.unpack(arc.First), ch.unpack(arc.Second)...)
+}
+
+// Save writes index as JSON
+func (ch *ContractionHierarchy) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(chFile{
+		Fingerprint: ch.fingerprint,
+		Keys:        ch.keys,
+		Rank:        ch.rank,
+		Arcs:        ch.arcs,
+	})
+}
+
+// LoadContractionHierarchy reads index saved by Save and binds it to graph it was built for
+func LoadContractionHierarchy(r io.Reader, gr *graph.Graph) (*ContractionHierarchy, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	var file chFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("cannot read contraction hierarchy: %v", err)
+	}
+	fingerprint := gr.Fingerprint()
+	if file.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("contraction hierarchy was built for another graph (fingerprint %x, graph has %x)", file.Fingerprint, fingerprint)
+	}
+
+	n := len(file.Keys)
+	if len(file.Rank) != n {
+		return nil, fmt.Errorf("contraction hierarchy is corrupted: %d ranks for %d nodes", len(file.Rank), n)
+	}
+	inRange := func(i, size int) bool { return i >= 0 && i < size }
+	for id, arc := range file.Arcs {
+		if !inRange(arc.From, n) || !inRange(arc.To, n) ||
+			arc.First != -1 && (!inRange(arc.First, len(file.Arcs)) || !inRange(arc.Second, len(file.Arcs))) {
+			return nil, fmt.Errorf("contraction hierarchy is corrupted: bad arc %d", id)
+		}
+	}
+
+	ch := &ContractionHierarchy{graph: gr, revision: gr.Revision(), fingerprint: fingerprint, rank: file.Rank, arcs: file.Arcs}
+	ch.setKeys(file.Keys)
+	ch.linkArcs()
+	return ch, nil
+}
+
+// FormatPointToPointResult creates a formatted string representation
+func (result *PointToPointResult) FormatPointToPointResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("SHORTEST PATH QUERY (%s)\n\n", result.Algorithm))
+	sb.WriteString(fmt.Sprintf("Source: %s\n", formatNodeName(gr, result.Source)))
+	sb.WriteString(fmt.Sprintf("Target: %s\n", formatNodeName(gr, result.Target)))
+	sb.WriteString(fmt.Sprintf("Nodes settled: %d of %d\n\n", result.Settled, len(gr.Nodes)))
+
+	if !result.Reachable {
+		sb.WriteString("Target is not reachable\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("Distance: %d\n", result.Path.Weight))
+	sb.WriteString(fmt.Sprintf("Path: %s\n", formatTrail(result.Path.Vertices)))
+	sb.WriteString(fmt.Sprintf("Edges: %s\n", formatKeyList(result.Path.Edges)))
+
+	return sb.String()
+}
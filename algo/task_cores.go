@@ -0,0 +1,272 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Count triangles, clustering coefficients and k-cores of graph
+ *
+ * All measures are taken on the underlying simple undirected graph: direction
+ * is ignored, parallel edges count once and loops are skipped.
+ *
+ * Triangle Counting - nodes are ranked by degree, and every edge is directed
+ * from lower to higher rank. Each triangle is then found exactly once, from
+ * its lowest node, by intersecting two short forward lists: O(E * sqrt(E)).
+ *
+ * Local Clustering - share of pairs of neighbors of v that are adjacent:
+ *   C(v) = 2 * T(v) / (d(v) * (d(v) - 1)), and 0 when d(v) < 2.
+ * Global (average) clustering is the mean of C(v) over all nodes.
+ * Transitivity - share of connected triples that are closed:
+ *   3 * triangles / Σ d(v) * (d(v) - 1) / 2.
+ *
+ * K-Core - maximal subgraph where every node has degree at least k. Core
+ * number of v is the largest k with v in the k-core. Nodes are peeled in order
+ * of current degree with bucket queue (Batagelj-Zaversnik), O(V + E). The
+ * peeling order is a degeneracy ordering: every node has at most degeneracy
+ * (largest core number) neighbors after it.
+ */
+
+// TrianglesResult represents triangles and clustering of graph
+type TrianglesResult struct {
+	Triangles         int                    // Total number of triangles
+	NodeTriangles     map[graph.TKey]int     // Number of triangles through every node
+	LocalClustering   map[graph.TKey]float64 // Clustering coefficient of every node
+	AverageClustering float64                // Mean of local coefficients
+	Transitivity      float64                // Share of closed connected triples
+	Message           string                 // Status message
+}
+
+// CoresResult represents k-core decomposition of graph
+type CoresResult struct {
+	CoreNumber map[graph.TKey]int // Core number of every node
+	Degeneracy int                // Largest core number
+	Ordering   []graph.TKey       // Degeneracy ordering, in order of peeling
+	Message    string             // Status message
+}
+
+// buildSimpleNeighbors returns sorted keys and sorted distinct neighbor indices in underlying simple undirected graph
+func buildSimpleNeighbors(gr *graph.Graph) ([]graph.TKey, [][]int, error) {
+	if gr.Nodes == nil {
+		return nil, nil, graph.ThrowNodesListIsNil()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	neighbors := make([][]int, len(keys))
+	for _, edge := range gr.Edges {
+		u, v := index[edge.Source], index[edge.Destination]
+		if u != v {
+			neighbors[u] = append(neighbors[u], v)
+			neighbors[v] = append(neighbors[v], u)
+		}
+	}
+	for i := range neighbors {
+		slices.Sort(neighbors[i])
+		neighbors[i] = slices.Compact(neighbors[i])
+	}
+	return keys, neighbors, nil
+}
+
+// CountTriangles counts triangles and computes clustering coefficients and transitivity
+func CountTriangles(gr *graph.Graph) (*TrianglesResult, error) {
+	keys, neighbors, err := buildSimpleNeighbors(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(keys)
+	// Rank by degree, ties by index: u precedes v if rank[u] < rank[v]
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return len(neighbors[a]) - len(neighbors[b]) })
+	rank := make([]int, n)
+	for r, v := range order {
+		rank[v] = r
+	}
+
+	forward := make([][]int, n)
+	for u := range neighbors {
+		for _, v := range neighbors[u] {
+			if rank[u] < rank[v] {
+				forward[u] = append(forward[u], v)
+			}
+		}
+	}
+
+	triangles := make([]int, n)
+	total := 0
+	mark := make([]bool, n)
+	for u := range forward {
+		for _, w := range forward[u] {
+			mark[w] = true
+		}
+		for _, v := range forward[u] {
+			for _, w := range forward[v] {
+				if mark[w] {
+					triangles[u]++
+					triangles[v]++
+					triangles[w]++
+					total++
+				}
+			}
+		}
+		for _, w := range forward[u] {
+			mark[w] = false
+		}
+	}
+
+	result := &TrianglesResult{
+		Triangles:       total,
+		NodeTriangles:   make(map[graph.TKey]int, n),
+		LocalClustering: make(map[graph.TKey]float64, n),
+	}
+	triples := 0
+	for i, key := range keys {
+		d := len(neighbors[i])
+		result.NodeTriangles[key] = triangles[i]
+		if d >= 2 {
+			result.LocalClustering[key] = 2 * float64(triangles[i]) / float64(d*(d-1))
+			triples += d * (d - 1) / 2
+		} else {
+			result.LocalClustering[key] = 0
+		}
+		result.AverageClustering += result.LocalClustering[key]
+	}
+	if n > 0 {
+		result.AverageClustering /= float64(n)
+	}
+	if triples > 0 {
+		result.Transitivity = 3 * float64(total) / float64(triples)
+	}
+
+	result.Message = fmt.Sprintf("Found %d triangles, transitivity %.4f", total, result.Transitivity)
+	return result, nil
+}
+
+// FindCoreDecomposition finds core number of every node and degeneracy ordering
+func FindCoreDecomposition(gr *graph.Graph) (*CoresResult, error) {
+	keys, neighbors, err := buildSimpleNeighbors(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(keys)
+	degree := make([]int, n)
+	maxDegree := 0
+	for v := range neighbors {
+		degree[v] = len(neighbors[v])
+		maxDegree = max(maxDegree, degree[v])
+	}
+
+	// Bucket queue: vertices sorted by current degree, start[d] is first position of degree d
+	start := make([]int, maxDegree+2)
+	for v := range degree {
+		start[degree[v]+1]++
+	}
+	for d := 1; d < len(start); d++ {
+		start[d] += start[d-1]
+	}
+	sorted := make([]int, n)
+	position := make([]int, n)
+	next := slices.Clone(start)
+	for v := range degree {
+		position[v] = next[degree[v]]
+		sorted[position[v]] = v
+		next[degree[v]]++
+	}
+
+	result := &CoresResult{
+		CoreNumber: make(map[graph.TKey]int, n),
+		Ordering:   make([]graph.TKey, 0, n),
+	}
+	for i := range n {
+		v := sorted[i]
+		result.CoreNumber[keys[v]] = degree[v]
+		result.Ordering = append(result.Ordering, keys[v])
+		result.Degeneracy = max(result.Degeneracy, degree[v])
+
+		// Every later neighbor with bigger degree moves one bucket down
+		for _, u := range neighbors[v] {
+			if degree[u] <= degree[v] {
+				continue
+			}
+			du := degree[u]
+			first := start[du]
+			w := sorted[first]
+			if w != u {
+				sorted[position[u]], sorted[first] = w, u
+				position[w], position[u] = position[u], first
+			}
+			start[du] = first + 1
+			degree[u]--
+		}
+	}
+
+	result.Message = fmt.Sprintf("Degeneracy of graph is %d", result.Degeneracy)
+	return result, nil
+}
+
+// KCoreSubgraph returns subgraph induced by nodes with core number at least k
+func KCoreSubgraph(gr *graph.Graph, k int) (*graph.Graph, error) {
+	cores, err := FindCoreDecomposition(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	subgraph := gr.Copy()
+	for key, core := range cores.CoreNumber {
+		if core < k {
+			delete(subgraph.Nodes, key)
+		}
+	}
+	for key, edge := range subgraph.Edges {
+		if _, ok := subgraph.Nodes[edge.Source]; !ok {
+			delete(subgraph.Edges, key)
+		} else if _, ok := subgraph.Nodes[edge.Destination]; !ok {
+			delete(subgraph.Edges, key)
+		}
+	}
+	subgraph.RebuildAdjacencyMap()
+
+	return subgraph, nil
+}
+
+// FormatCoresResult creates a formatted string representation
+func (result *CoresResult) FormatCoresResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("K-CORE DECOMPOSITION\n\n")
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Degeneracy: %d\n", result.Degeneracy))
+	sb.WriteString(fmt.Sprintf("Degeneracy ordering: %s\n\n", formatKeyList(result.Ordering)))
+
+	sb.WriteString(fmt.Sprintf("%-8s %s\n", "Core", "Nodes"))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	shells := make(map[int][]graph.TKey)
+	for _, key := range getSortedKeys(gr.Nodes) {
+		core := result.CoreNumber[key]
+		shells[core] = append(shells[core], key)
+	}
+	for core := result.Degeneracy; core >= 0; core-- {
+		if len(shells[core]) > 0 {
+			sb.WriteString(fmt.Sprintf("%-8d %s\n", core, formatKeyList(shells[core])))
+		}
+	}
+
+	return sb.String()
+}
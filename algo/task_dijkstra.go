@@ -5,8 +5,10 @@
 package algo
 
 import (
+	"container/heap"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 
@@ -185,6 +187,144 @@ func dijkstra(gr *graph.Graph, source graph.TKey) (map[graph.TKey]int64, error)
 	return distances, nil
 }
 
+/*
+ * Heap-based Dijkstra. The one above scans all vertices to pick the next one
+ * and all edges to find a weight, which is fine for small course graphs. The
+ * version below works on prebuilt arc lists with a binary heap, so other
+ * algorithms (postman routes, Johnson, etc.) can call it many times. Dijkstra
+ * is its public form, with the same result as Bellman-Ford and SPFA.
+ */
+
+// weightedArc is an outgoing arc of an arc list, remembering its original edge
+type weightedArc struct {
+	to     graph.TKey
+	key    graph.TKey
+	weight int64
+}
+
+// pathStep tells how a vertex was reached in a shortest path tree
+type pathStep struct {
+	from graph.TKey
+	edge graph.TKey
+}
+
+// shortestPathTree is the result of heap-based Dijkstra from a single source
+type shortestPathTree struct {
+	source graph.TKey
+	dist   map[graph.TKey]int64    // Distances to reached vertices only
+	prev   map[graph.TKey]pathStep // Last step of the shortest path to each reached vertex
+	order  []graph.TKey            // Vertices in the order they were settled
+}
+
+// buildArcLists builds outgoing arc lists. Undirected edges produce arcs in both directions
+func buildArcLists(gr *graph.Graph) map[graph.TKey][]weightedArc {
+	arcs := make(map[graph.TKey][]weightedArc)
+	for _, edge := range gr.Edges {
+		weight := int64(edge.Weight)
+		arcs[edge.Source] = append(arcs[edge.Source], weightedArc{to: edge.Destination, key: edge.Key, weight: weight})
+		if !gr.Options.IsDirected && edge.Source != edge.Destination {
+			arcs[edge.Destination] = append(arcs[edge.Destination], weightedArc{to: edge.Source, key: edge.Key, weight: weight})
+		}
+	}
+	return arcs
+}
+
+// distanceItem is a heap entry. Outdated entries are skipped when popped
+type distanceItem struct {
+	vertex graph.TKey
+	dist   int64
+}
+
+type distanceHeap []distanceItem
+
+func (h distanceHeap) Len() int           { return len(h) }
+func (h distanceHeap) Less(i, j int) bool { return h[i].dist < h[j].dist }
+func (h distanceHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *distanceHeap) Push(x any)        { *h = append(*h, x.(distanceItem)) }
+func (h *distanceHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// dijkstraHeap runs Dijkstra with a binary heap over arc lists
+// Time Complexity: O(E log V). Arc weights must be non-negative
+func dijkstraHeap(arcs map[graph.TKey][]weightedArc, source graph.TKey) *shortestPathTree {
+	tree := &shortestPathTree{
+		source: source,
+		dist:   map[graph.TKey]int64{source: 0},
+		prev:   make(map[graph.TKey]pathStep),
+	}
+	settled := make(map[graph.TKey]bool)
+	pq := &distanceHeap{{vertex: source, dist: 0}}
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(distanceItem)
+		if settled[item.vertex] {
+			continue
+		}
+		settled[item.vertex] = true
+		tree.order = append(tree.order, item.vertex)
+
+		for _, arc := range arcs[item.vertex] {
+			newDist := item.dist + arc.weight
+			if current, reached := tree.dist[arc.to]; !reached || newDist < current {
+				tree.dist[arc.to] = newDist
+				tree.prev[arc.to] = pathStep{from: item.vertex, edge: arc.key}
+				heap.Push(pq, distanceItem{vertex: arc.to, dist: newDist})
+			}
+		}
+	}
+
+	return tree
+}
+
+// pathTo reconstructs vertices and edge keys of the shortest path to target
+// Returns nil slices if target was not reached
+func (tree *shortestPathTree) pathTo(target graph.TKey) ([]graph.TKey, []graph.TKey) {
+	if _, reached := tree.dist[target]; !reached {
+		return nil, nil
+	}
+
+	vertices := []graph.TKey{target}
+	edges := []graph.TKey{}
+	for current := target; current != tree.source; {
+		step := tree.prev[current]
+		vertices = append(vertices, step.from)
+		edges = append(edges, step.edge)
+		current = step.from
+	}
+
+	slices.Reverse(vertices)
+	slices.Reverse(edges)
+	return vertices, edges
+}
+
+// Dijkstra finds shortest paths from src with heap-based Dijkstra
+// Time Complexity: O(E log V). Edge weights must be non-negative
+func Dijkstra(gr *graph.Graph, src graph.TKey) (*BellmanFordResult, error) {
+	if err := validateSingleSource(gr, src); err != nil {
+		return nil, err
+	}
+	if err := checkNonNegativeWeights(gr); err != nil {
+		return nil, err
+	}
+
+	tree := dijkstraHeap(buildArcLists(gr), src)
+	return buildBellmanFordResult(src, tree.dist, tree.prev, nil, "Dijkstra"), nil
+}
+
+// checkNonNegativeWeights returns error for the first negative edge
+func checkNonNegativeWeights(gr *graph.Graph) error {
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		if edge := gr.Edges[key]; edge.Weight < 0 {
+			return fmt.Errorf("Dijkstra's algorithm cannot handle negative weights. Edge %d has weight %d", edge.Key, edge.Weight)
+		}
+	}
+	return nil
+}
+
 // getEdgeWeight finds the weight of an edge between two vertices
 func getEdgeWeight(gr *graph.Graph, u, v graph.TKey) int64 {
 	for _, edge := range gr.Edges {
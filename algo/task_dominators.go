@@ -0,0 +1,295 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find dominators of directed graph from entry node
+ *
+ * Node d dominates v if every path from entry to v goes through d. Immediate
+ * dominator idom(v) is the closest strict dominator; edges idom(v) → v form
+ * the dominator tree rooted at entry. Post-dominators are dominators of the
+ * reversed graph from exit node: every path from v to exit goes through them.
+ *
+ * Lengauer-Tarjan Algorithm:
+ *   1. DFS from entry numbers nodes in preorder
+ *   2. Semidominator sdom(w) is the smallest-numbered node with a path to w
+ *      whose inner nodes all have bigger numbers. Nodes are processed in
+ *      reverse preorder with path compressed forest to find minimums
+ *   3. idom(w) = sdom(w) if the node with smallest sdom on tree path between
+ *      them is w itself, otherwise idom(w) = idom of that node
+ * O(E log V) with simple path compression.
+ *
+ * Dominance Frontier of d - nodes where dominance of d ends: v is in DF(d) if
+ * d dominates a predecessor of v but does not strictly dominate v. Found by
+ * walking up the dominator tree from every predecessor of v until idom(v)
+ * (Cooper, Harvey, Kennedy). Used to place phi-functions in SSA form.
+ */
+
+// DominatorResult represents dominators of nodes reachable from root
+type DominatorResult struct {
+	Root           graph.TKey                  // Entry node, or exit node for post-dominators
+	PostDominators bool                        // Computed on reversed graph
+	Immediate      map[graph.TKey]graph.TKey   // Immediate dominator of every reachable node except root
+	Frontiers      map[graph.TKey][]graph.TKey // Dominance frontier of every reachable node
+	Unreachable    []graph.TKey                // Nodes not reachable from entry (not reaching exit)
+	Tree           *graph.Graph                // Dominator tree with edges from immediate dominators
+	Message        string                      // Status message
+}
+
+// FindDominators finds immediate dominators, dominator tree and dominance frontiers from entry
+func FindDominators(gr *graph.Graph, entry graph.TKey) (*DominatorResult, error) {
+	return findDominators(gr, entry, false)
+}
+
+// FindPostDominators finds immediate post-dominators, their tree and frontiers towards exit
+func FindPostDominators(gr *graph.Graph, exit graph.TKey) (*DominatorResult, error) {
+	return findDominators(gr, exit, true)
+}
+
+// findDominators runs Lengauer-Tarjan from root, on reversed arcs if asked
+func findDominators(gr *graph.Graph, root graph.TKey, reversed bool) (*DominatorResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if !gr.Options.IsDirected {
+		return nil, graph.ThrowGraphNotDirected()
+	}
+	if _, err := gr.GetNodeByKey(root); err != nil {
+		return nil, err
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+	n := len(keys)
+	successors := make([][]int, n)
+	predecessors := make([][]int, n)
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		u, v := index[edge.Source], index[edge.Destination]
+		if reversed {
+			u, v = v, u
+		}
+		successors[u] = append(successors[u], v)
+		predecessors[v] = append(predecessors[v], u)
+	}
+
+	// DFS numbering from 1, 0 is for unreachable nodes
+	number := make([]int, n)
+	vertex := []int{-1} // Node of every number
+	parent := make([]int, n)
+	var dfs func(v int)
+	dfs = func(v int) {
+		vertex = append(vertex, v)
+		number[v] = len(vertex) - 1
+		for _, w := range successors[v] {
+			if number[w] == 0 {
+				parent[w] = v
+				dfs(w)
+			}
+		}
+	}
+	r := index[root]
+	parent[r] = -1
+	dfs(r)
+
+	// Forest with path compression over semidominator numbers
+	semi := make([]int, n)
+	ancestor := make([]int, n)
+	label := make([]int, n)
+	for v := range n {
+		semi[v] = number[v]
+		ancestor[v] = -1
+		label[v] = v
+	}
+	var compress func(v int)
+	compress = func(v int) {
+		if ancestor[ancestor[v]] == -1 {
+			return
+		}
+		compress(ancestor[v])
+		if semi[label[ancestor[v]]] < semi[label[v]] {
+			label[v] = label[ancestor[v]]
+		}
+		ancestor[v] = ancestor[ancestor[v]]
+	}
+	eval := func(v int) int {
+		if ancestor[v] == -1 {
+			return v
+		}
+		compress(v)
+		return label[v]
+	}
+
+	idom := make([]int, n)
+	for v := range idom {
+		idom[v] = -1
+	}
+	bucket := make([][]int, n)
+	for i := len(vertex) - 1; i >= 2; i-- {
+		w := vertex[i]
+		for _, v := range predecessors[w] {
+			if number[v] == 0 {
+				continue
+			}
+			if u := eval(v); semi[u] < semi[w] {
+				semi[w] = semi[u]
+			}
+		}
+		s := vertex[semi[w]]
+		bucket[s] = append(bucket[s], w)
+		ancestor[w] = parent[w]
+
+		for _, v := range bucket[parent[w]] {
+			if u := eval(v); semi[u] < semi[v] {
+				idom[v] = u
+			} else {
+				idom[v] = parent[w]
+			}
+		}
+		bucket[parent[w]] = nil
+	}
+	for i := 2; i < len(vertex); i++ {
+		w := vertex[i]
+		if idom[w] != vertex[semi[w]] {
+			idom[w] = idom[idom[w]]
+		}
+	}
+
+	result := &DominatorResult{
+		Root:           root,
+		PostDominators: reversed,
+		Immediate:      make(map[graph.TKey]graph.TKey),
+		Frontiers:      make(map[graph.TKey][]graph.TKey),
+		Unreachable:    []graph.TKey{},
+	}
+	for v, key := range keys {
+		switch {
+		case number[v] == 0:
+			result.Unreachable = append(result.Unreachable, key)
+		case v != r:
+			result.Immediate[key] = keys[idom[v]]
+		}
+	}
+
+	// Dominance frontiers: walk up from every predecessor until idom of the node
+	frontiers := make([]map[int]bool, n)
+	for v := range n {
+		if number[v] == 0 {
+			continue
+		}
+		frontiers[v] = make(map[int]bool)
+	}
+	for v := range n {
+		if number[v] == 0 {
+			continue
+		}
+		for _, p := range predecessors[v] {
+			if number[p] == 0 {
+				continue
+			}
+			for runner := p; runner != -1 && runner != idom[v]; runner = idom[runner] {
+				frontiers[runner][v] = true
+			}
+		}
+	}
+	for v, key := range keys {
+		if number[v] == 0 {
+			continue
+		}
+		frontier := make([]graph.TKey, 0, len(frontiers[v]))
+		for w := range frontiers[v] {
+			frontier = append(frontier, keys[w])
+		}
+		slices.Sort(frontier)
+		result.Frontiers[key] = frontier
+	}
+
+	tree, err := result.buildTree(gr)
+	if err != nil {
+		return nil, err
+	}
+	result.Tree = tree
+
+	kind := "Dominator"
+	if reversed {
+		kind = "Post-dominator"
+	}
+	result.Message = fmt.Sprintf("%s tree of %d nodes built from %d", kind, len(result.Immediate)+1, root)
+	return result, nil
+}
+
+// buildTree makes directed graph on reachable nodes with edges from immediate dominators
+func (result *DominatorResult) buildTree(gr *graph.Graph) (*graph.Graph, error) {
+	tree := graph.MakeGraph(graph.WithGraphDirected(true))
+	for _, key := range getSortedKeys(gr.Nodes) {
+		if _, ok := result.Immediate[key]; !ok && key != result.Root {
+			continue
+		}
+		if err := tree.AddNode(graph.MakeNode(key, graph.WithNodeLabel(gr.Nodes[key].Label))); err != nil {
+			return nil, err
+		}
+	}
+	for i, key := range getSortedMapKeys(result.Immediate) {
+		if err := tree.AddEdge(graph.MakeEdge(graph.TKey(i+1), result.Immediate[key], key)); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// Dominators lists all dominators of node from root down to the node itself
+func (result *DominatorResult) Dominators(key graph.TKey) []graph.TKey {
+	if _, ok := result.Frontiers[key]; !ok {
+		return nil
+	}
+	chain := []graph.TKey{key}
+	for key != result.Root {
+		key = result.Immediate[key]
+		chain = append(chain, key)
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+// FormatDominatorResult creates a formatted string representation
+func (result *DominatorResult) FormatDominatorResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	kind, rootName := "DOMINATORS", "Entry"
+	if result.PostDominators {
+		kind, rootName = "POST-DOMINATORS", "Exit"
+	}
+	sb.WriteString(fmt.Sprintf("%s (Lengauer-Tarjan)\n\n", kind))
+	sb.WriteString(fmt.Sprintf("%s node: %s\n", rootName, formatNodeName(gr, result.Root)))
+	sb.WriteString(fmt.Sprintf("Reachable nodes: %d\n", len(result.Frontiers)))
+	if len(result.Unreachable) > 0 {
+		sb.WriteString(fmt.Sprintf("Unreachable nodes: %s\n", formatKeyList(result.Unreachable)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(fmt.Sprintf("%-16s %-10s %-24s %s\n", "Node", "Idom", "Dominators", "Frontier"))
+	sb.WriteString(strings.Repeat("─", 70) + "\n")
+	for _, key := range getSortedMapKeys(result.Frontiers) {
+		idom := "—"
+		if parent, ok := result.Immediate[key]; ok {
+			idom = fmt.Sprintf("%d", parent)
+		}
+		sb.WriteString(fmt.Sprintf("%-16s %-10s %-24s %s\n",
+			formatNodeName(gr, key), idom, formatTrail(result.Dominators(key)), formatKeyList(result.Frontiers[key])))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,560 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find Eulerian path or circuit using Hierholzer's algorithm, and a
+ * Chinese postman route for undirected weighted graphs
+ *
+ * Eulerian trail - walk that uses every edge exactly once
+ * Eulerian circuit - Eulerian trail that starts and ends in the same vertex
+ * Chinese postman route - shortest closed walk that uses every edge at least once
+ *
+ * Edges are told apart by key, so parallel edges of multigraphs are walked
+ * separately and loops are walked once.
+ */
+
+// EulerianResult represents the result of Eulerian trail search
+type EulerianResult struct {
+	HasCircuit bool         // Whether Eulerian circuit exists
+	HasPath    bool         // Whether Eulerian trail exists (circuit is a trail too)
+	Vertices   []graph.TKey // Vertex sequence of the found trail
+	Edges      []graph.TKey // Edge keys in traversal order
+	Reasons    []string     // Why no Eulerian trail exists, empty if it does
+	Message    string       // Status message
+}
+
+// ChinesePostmanResult represents the result of Chinese postman route search
+type ChinesePostmanResult struct {
+	TotalWeight     graph.TWeight // Weight of the whole route
+	OriginalWeight  graph.TWeight // Sum of weights of all edges
+	Vertices        []graph.TKey  // Vertex sequence of the route
+	Edges           []graph.TKey  // Edge keys in route order, repeated edges appear several times
+	DuplicatedEdges []graph.TKey  // Edge keys walked once more, one entry per extra walk
+	IsOptimal       bool          // False if odd vertices were too many and got paired greedily
+	Message         string        // Status message
+}
+
+// trailEdge is an edge of (possibly augmented) multigraph walked by Hierholzer
+type trailEdge struct {
+	key  graph.TKey
+	u, v graph.TKey
+}
+
+// maxExactPostmanOdd limits exact pairing of odd vertices, which is O(2^k * k)
+const maxExactPostmanOdd = 20
+
+// FindEulerianTrail finds Eulerian circuit if it exists, otherwise Eulerian path
+// Time Complexity: O(V + E)
+func FindEulerianTrail(gr *graph.Graph) (*EulerianResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	edges := collectTrailEdges(gr)
+	if len(edges) == 0 {
+		return &EulerianResult{
+			HasCircuit: true,
+			HasPath:    true,
+			Vertices:   []graph.TKey{},
+			Edges:      []graph.TKey{},
+			Reasons:    []string{},
+			Message:    "Graph has no edges - trivial Eulerian circuit",
+		}, nil
+	}
+
+	reasons := []string{}
+	if parts := edgeComponents(gr); len(parts) > 1 {
+		reasons = append(reasons, describeEdgeComponents(parts))
+	}
+
+	start, isCircuit, degreeReasons := findEulerianStart(gr)
+	reasons = append(reasons, degreeReasons...)
+
+	if len(reasons) > 0 {
+		return &EulerianResult{
+			Vertices: []graph.TKey{},
+			Edges:    []graph.TKey{},
+			Reasons:  reasons,
+			Message:  "Graph has no Eulerian trail",
+		}, nil
+	}
+
+	vertices, keys := hierholzer(edges, gr.Options.IsDirected, start)
+
+	message := fmt.Sprintf("Found Eulerian path from %d to %d", vertices[0], vertices[len(vertices)-1])
+	if isCircuit {
+		message = fmt.Sprintf("Found Eulerian circuit through %d edges", len(keys))
+	}
+
+	return &EulerianResult{
+		HasCircuit: isCircuit,
+		HasPath:    true,
+		Vertices:   vertices,
+		Edges:      keys,
+		Reasons:    reasons,
+		Message:    message,
+	}, nil
+}
+
+// findEulerianStart checks degree conditions and picks the vertex to start from
+// Returns start vertex, whether trail is closed, and reasons if conditions fail
+func findEulerianStart(gr *graph.Graph) (graph.TKey, bool, []string) {
+	keys := getSortedKeys(gr.Nodes)
+	reasons := []string{}
+
+	// Start from the smallest vertex that has edges, unless degrees force another one
+	var start graph.TKey
+	first := true
+	for _, edge := range gr.Edges {
+		for _, end := range []graph.TKey{edge.Source, edge.Destination} {
+			if first || end < start {
+				start, first = end, false
+			}
+		}
+	}
+
+	if !gr.Options.IsDirected {
+		degree := make(map[graph.TKey]int)
+		for _, edge := range gr.Edges {
+			degree[edge.Source]++
+			degree[edge.Destination]++ // Loop adds 2 to its vertex, as it should
+		}
+
+		odd := []graph.TKey{}
+		for _, key := range keys {
+			if degree[key]%2 != 0 {
+				odd = append(odd, key)
+			}
+		}
+
+		switch len(odd) {
+		case 0:
+			return start, true, reasons
+		case 2:
+			return odd[0], false, reasons
+		default:
+			reasons = append(reasons, fmt.Sprintf(
+				"%d vertices have odd degree (at most 2 allowed): %s", len(odd), formatKeyList(odd)))
+			return start, false, reasons
+		}
+	}
+
+	balance := make(map[graph.TKey]int)
+	for _, edge := range gr.Edges {
+		balance[edge.Source]++
+		balance[edge.Destination]--
+	}
+
+	var starts, ends, broken []graph.TKey
+	for _, key := range keys {
+		switch {
+		case balance[key] == 1:
+			starts = append(starts, key)
+		case balance[key] == -1:
+			ends = append(ends, key)
+		case balance[key] != 0:
+			broken = append(broken, key)
+		}
+	}
+
+	if len(starts) == 0 && len(ends) == 0 && len(broken) == 0 {
+		return start, true, reasons
+	}
+	if len(starts) == 1 && len(ends) == 1 && len(broken) == 0 {
+		return starts[0], false, reasons
+	}
+
+	if len(broken) > 0 {
+		parts := make([]string, len(broken))
+		for i, key := range broken {
+			parts[i] = fmt.Sprintf("%d (out-in = %+d)", key, balance[key])
+		}
+		reasons = append(reasons, "Vertices with out-degree and in-degree differing by more than 1: "+strings.Join(parts, ", "))
+	}
+	if len(starts) > 1 || len(ends) > 1 || len(starts) != len(ends) {
+		reasons = append(reasons, fmt.Sprintf(
+			"Need at most one vertex with out-in = +1 and one with out-in = -1, got %d (%s) and %d (%s)",
+			len(starts), formatKeyList(starts), len(ends), formatKeyList(ends)))
+	}
+
+	return start, false, reasons
+}
+
+// hierholzer walks every edge exactly once starting from start
+// Degree and connectivity conditions must be checked beforehand
+func hierholzer(edges []trailEdge, directed bool, start graph.TKey) ([]graph.TKey, []graph.TKey) {
+	// Incidence lists hold indices into edges, so duplicated edges stay distinct
+	incidence := make(map[graph.TKey][]int)
+	for i, edge := range edges {
+		incidence[edge.u] = append(incidence[edge.u], i)
+		if !directed && edge.u != edge.v {
+			incidence[edge.v] = append(incidence[edge.v], i)
+		}
+	}
+
+	used := make([]bool, len(edges))
+	next := make(map[graph.TKey]int)
+
+	type frame struct {
+		vertex graph.TKey
+		edge   int // Index of edge we came by, -1 for start
+	}
+	stack := []frame{{vertex: start, edge: -1}}
+	var vertices, keys []graph.TKey
+
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+
+		// Skip edges already walked from the other end
+		for next[top.vertex] < len(incidence[top.vertex]) && used[incidence[top.vertex][next[top.vertex]]] {
+			next[top.vertex]++
+		}
+
+		if next[top.vertex] < len(incidence[top.vertex]) {
+			index := incidence[top.vertex][next[top.vertex]]
+			used[index] = true
+
+			to := edges[index].v
+			if to == top.vertex {
+				to = edges[index].u
+			}
+			stack = append(stack, frame{vertex: to, edge: index})
+			continue
+		}
+
+		// Dead end: vertex is finished, so it goes to the trail
+		stack = stack[:len(stack)-1]
+		vertices = append(vertices, top.vertex)
+		if top.edge >= 0 {
+			keys = append(keys, edges[top.edge].key)
+		}
+	}
+
+	// Vertices were collected from the end of the trail
+	slices.Reverse(vertices)
+	slices.Reverse(keys)
+
+	return vertices, keys
+}
+
+// FindChinesePostmanRoute finds the shortest closed walk using every edge
+// Odd degree vertices are paired by minimum total shortest path distance, those
+// paths are walked twice, and the resulting Eulerian multigraph gives the route
+func FindChinesePostmanRoute(gr *graph.Graph) (*ChinesePostmanResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if gr.Options.IsDirected {
+		return nil, graph.ThrowGraphDirected()
+	}
+
+	originalWeight := graph.TWeight(0)
+	for _, edge := range gr.Edges {
+		if edge.Weight < 0 {
+			return nil, fmt.Errorf("Chinese postman route cannot handle negative weights. Edge %d has weight %d", edge.Key, edge.Weight)
+		}
+		originalWeight += edge.Weight
+	}
+
+	edges := collectTrailEdges(gr)
+	if len(edges) == 0 {
+		return &ChinesePostmanResult{
+			Vertices:        []graph.TKey{},
+			Edges:           []graph.TKey{},
+			DuplicatedEdges: []graph.TKey{},
+			IsOptimal:       true,
+			Message:         "Graph has no edges - empty route",
+		}, nil
+	}
+
+	if parts := edgeComponents(gr); len(parts) > 1 {
+		return nil, fmt.Errorf("no closed route exists: %s", describeEdgeComponents(parts))
+	}
+
+	// Step 1: Find odd degree vertices
+	degree := make(map[graph.TKey]int)
+	for _, edge := range gr.Edges {
+		degree[edge.Source]++
+		degree[edge.Destination]++
+	}
+	odd := []graph.TKey{}
+	for _, key := range getSortedKeys(gr.Nodes) {
+		if degree[key]%2 != 0 {
+			odd = append(odd, key)
+		}
+	}
+
+	// Step 2: Shortest paths from every odd vertex
+	arcs := buildArcLists(gr)
+	trees := make([]*shortestPathTree, len(odd))
+	for i, vertex := range odd {
+		trees[i] = dijkstraHeap(arcs, vertex)
+	}
+
+	// Step 3: Pair odd vertices with minimum total distance
+	var pairs [][2]int
+	isOptimal := true
+	if len(odd) <= maxExactPostmanOdd {
+		pairs = pairOddVerticesExact(odd, trees)
+	} else {
+		pairs = pairOddVerticesGreedy(odd, trees)
+		isOptimal = false
+	}
+
+	// Step 4: Duplicate edges of every pairing path
+	duplicated := []graph.TKey{}
+	for _, pair := range pairs {
+		_, pathEdges := trees[pair[0]].pathTo(odd[pair[1]])
+		for _, key := range pathEdges {
+			edge := gr.Edges[key]
+			edges = append(edges, trailEdge{key: key, u: edge.Source, v: edge.Destination})
+			duplicated = append(duplicated, key)
+		}
+	}
+	sort.Slice(duplicated, func(i, j int) bool { return duplicated[i] < duplicated[j] })
+
+	// Step 5: Augmented multigraph is Eulerian, walk it
+	vertices, keys := hierholzer(edges, false, edges[0].u)
+
+	totalWeight := graph.TWeight(0)
+	for _, key := range keys {
+		totalWeight += gr.Edges[key].Weight
+	}
+
+	return &ChinesePostmanResult{
+		TotalWeight:     totalWeight,
+		OriginalWeight:  originalWeight,
+		Vertices:        vertices,
+		Edges:           keys,
+		DuplicatedEdges: duplicated,
+		IsOptimal:       isOptimal,
+		Message:         fmt.Sprintf("Found route of weight %d through %d edges", totalWeight, len(keys)),
+	}, nil
+}
+
+// pairOddVerticesExact finds minimum weight perfect matching of odd vertices by
+// DP over subsets: best[mask] is the cheapest pairing of vertices in mask
+func pairOddVerticesExact(odd []graph.TKey, trees []*shortestPathTree) [][2]int {
+	k := len(odd)
+	full := 1<<k - 1
+	best := make([]int64, full+1)
+	choice := make([]int, full+1)
+
+	for mask := 1; mask <= full; mask++ {
+		best[mask] = math.MaxInt64
+		if bits.OnesCount(uint(mask))%2 != 0 {
+			continue
+		}
+
+		// Lowest vertex in mask must be paired with somebody
+		i := bits.TrailingZeros(uint(mask))
+		for j := i + 1; j < k; j++ {
+			if mask&(1<<j) == 0 {
+				continue
+			}
+			rest := mask &^ (1<<i | 1<<j)
+			if best[rest] == math.MaxInt64 {
+				continue
+			}
+			if cost := trees[i].dist[odd[j]] + best[rest]; cost < best[mask] {
+				best[mask] = cost
+				choice[mask] = j
+			}
+		}
+	}
+
+	pairs := [][2]int{}
+	for mask := full; mask != 0; {
+		i := bits.TrailingZeros(uint(mask))
+		j := choice[mask]
+		pairs = append(pairs, [2]int{i, j})
+		mask &^= 1<<i | 1<<j
+	}
+	return pairs
+}
+
+// pairOddVerticesGreedy repeatedly pairs the closest pair of unpaired odd vertices
+func pairOddVerticesGreedy(odd []graph.TKey, trees []*shortestPathTree) [][2]int {
+	type candidate struct {
+		i, j int
+		dist int64
+	}
+
+	candidates := []candidate{}
+	for i := range odd {
+		for j := i + 1; j < len(odd); j++ {
+			candidates = append(candidates, candidate{i: i, j: j, dist: trees[i].dist[odd[j]]})
+		}
+	}
+	sort.Slice(candidates, func(a, b int) bool { return candidates[a].dist < candidates[b].dist })
+
+	paired := make([]bool, len(odd))
+	pairs := [][2]int{}
+	for _, c := range candidates {
+		if !paired[c.i] && !paired[c.j] {
+			paired[c.i], paired[c.j] = true, true
+			pairs = append(pairs, [2]int{c.i, c.j})
+		}
+	}
+	return pairs
+}
+
+// Helper functions for graph operations
+
+// collectTrailEdges lists edges in key order for deterministic trails
+func collectTrailEdges(gr *graph.Graph) []trailEdge {
+	edges := make([]trailEdge, 0, len(gr.Edges))
+	for _, edge := range gr.Edges {
+		edges = append(edges, trailEdge{key: edge.Key, u: edge.Source, v: edge.Destination})
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].key < edges[j].key })
+	return edges
+}
+
+// edgeComponents groups vertices having edges by weakly connected components.
+// Isolated vertices are ignored, they don't prevent a trail
+func edgeComponents(gr *graph.Graph) [][]graph.TKey {
+	incidence := buildUndirectedIncidence(gr)
+	visited := make(map[graph.TKey]bool)
+	components := [][]graph.TKey{}
+
+	for _, start := range getSortedKeys(gr.Nodes) {
+		if visited[start] || len(incidence[start]) == 0 {
+			continue
+		}
+
+		component := []graph.TKey{}
+		queue := []graph.TKey{start}
+		visited[start] = true
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			component = append(component, current)
+			for _, inc := range incidence[current] {
+				if !visited[inc.to] {
+					visited[inc.to] = true
+					queue = append(queue, inc.to)
+				}
+			}
+		}
+
+		sort.Slice(component, func(i, j int) bool { return component[i] < component[j] })
+		components = append(components, component)
+	}
+
+	return components
+}
+
+// describeEdgeComponents explains which parts of the graph are disconnected
+func describeEdgeComponents(parts [][]graph.TKey) string {
+	descriptions := make([]string, len(parts))
+	for i, part := range parts {
+		descriptions[i] = "{" + formatKeyList(part) + "}"
+	}
+	return fmt.Sprintf("Edges are split into %d disconnected parts: %s", len(parts), strings.Join(descriptions, ", "))
+}
+
+// formatTrail formats vertex sequence as "1 → 2 → 3"
+func formatTrail(vertices []graph.TKey) string {
+	parts := make([]string, len(vertices))
+	for i, vertex := range vertices {
+		parts[i] = fmt.Sprintf("%d", vertex)
+	}
+	return strings.Join(parts, " → ")
+}
+
+// FormatEulerianResult creates a formatted string representation
+func (result *EulerianResult) FormatEulerianResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("EULERIAN TRAIL ANALYSIS\n\n")
+	sb.WriteString("Algorithm: Hierholzer\n")
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Total edges: %d\n", len(gr.Edges)))
+	sb.WriteString(fmt.Sprintf("Graph directed: %v\n", gr.Options.IsDirected))
+	sb.WriteString(fmt.Sprintf("Eulerian circuit: %v\n", result.HasCircuit))
+	sb.WriteString(fmt.Sprintf("Eulerian path: %v\n\n", result.HasPath))
+
+	if !result.HasPath {
+		sb.WriteString("WHY NO EULERIAN TRAIL EXISTS:\n")
+		sb.WriteString(strings.Repeat("─", 50) + "\n")
+		for i, reason := range result.Reasons {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, reason))
+		}
+		return sb.String()
+	}
+
+	if len(result.Edges) == 0 {
+		sb.WriteString("Graph has no edges to walk.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("TRAIL:\n")
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	sb.WriteString(formatTrail(result.Vertices) + "\n\n")
+
+	sb.WriteString("EDGES IN ORDER:\n")
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for i, key := range result.Edges {
+		sb.WriteString(fmt.Sprintf("%d. Edge %d: %s → %s\n", i+1, key,
+			formatNodeName(gr, result.Vertices[i]), formatNodeName(gr, result.Vertices[i+1])))
+	}
+
+	return sb.String()
+}
+
+// FormatChinesePostmanResult creates a formatted string representation
+func (result *ChinesePostmanResult) FormatChinesePostmanResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("CHINESE POSTMAN ROUTE\n\n")
+	sb.WriteString("Algorithm: odd vertex pairing + Hierholzer\n")
+	sb.WriteString(fmt.Sprintf("Total edges: %d\n", len(gr.Edges)))
+	sb.WriteString(fmt.Sprintf("Sum of edge weights: %d\n", result.OriginalWeight))
+	sb.WriteString(fmt.Sprintf("Route weight: %d\n", result.TotalWeight))
+	sb.WriteString(fmt.Sprintf("Extra weight: %d\n", result.TotalWeight-result.OriginalWeight))
+	if !result.IsOptimal {
+		sb.WriteString(fmt.Sprintf("Note: more than %d odd vertices, paired greedily - route may be not optimal\n", maxExactPostmanOdd))
+	}
+	sb.WriteString("\n")
+
+	if len(result.Edges) == 0 {
+		sb.WriteString("Graph has no edges to walk.\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("DUPLICATED EDGES (%d):\n", len(result.DuplicatedEdges)))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	if len(result.DuplicatedEdges) == 0 {
+		sb.WriteString("None - graph is Eulerian\n")
+	} else {
+		sb.WriteString(formatKeyList(result.DuplicatedEdges) + "\n")
+	}
+
+	sb.WriteString("\nROUTE:\n")
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	sb.WriteString(formatTrail(result.Vertices) + "\n\n")
+
+	sb.WriteString("EDGES IN ORDER:\n")
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for i, key := range result.Edges {
+		sb.WriteString(fmt.Sprintf("%d. Edge %d: %s - %s [Weight: %d]\n", i+1, key,
+			formatNodeName(gr, result.Vertices[i]), formatNodeName(gr, result.Vertices[i+1]), gr.Edges[key].Weight))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,211 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Decompose flow into source → sink paths and cycles
+ *
+ * Any flow is a sum of flows along simple paths from sources to sinks and
+ * along cycles. Walk from a source following edges that still carry flow: at
+ * intermediate vertices flow conservation guarantees a way out, so the walk
+ * either reaches a sink (path found) or returns to a vertex it has already
+ * visited (cycle found). The smallest flow on the path or cycle is subtracted
+ * from all its edges, which empties at least one edge. So there are at most E
+ * paths and cycles, and the whole decomposition takes O(V * E).
+ *
+ * Cycles carry no flow from sources to sinks, they only appear when flow goes
+ * around in a loop, which max-flow algorithms are free to leave.
+ */
+
+// FlowPath is a path or a cycle carrying the same amount of flow along all its edges
+type FlowPath struct {
+	Vertices []graph.TKey  `json:"vertices"` // Vertices in order, cycle does not repeat the first one
+	Edges    []graph.TKey  `json:"edges"`    // Keys of edges between consecutive vertices
+	Amount   graph.TWeight `json:"amount"`   // Flow sent along the path or cycle
+}
+
+// FlowDecomposition represents flow split into paths and cycles
+type FlowDecomposition struct {
+	Paths   []FlowPath `json:"paths"`
+	Cycles  []FlowPath `json:"cycles"`
+	Message string     `json:"message"`
+}
+
+// flowArc is an edge with flow left to decompose, oriented along the flow
+type flowArc struct {
+	key      graph.TKey
+	from, to graph.TKey
+	amount   graph.TWeight
+}
+
+// DecomposeFlow splits flow given by edges into paths from sources to sinks and cycles
+func DecomposeFlow(flowEdges []FlowEdge, sources, sinks []graph.TKey) *FlowDecomposition {
+	arcs := make([]*flowArc, 0, len(flowEdges))
+	outgoing := make(map[graph.TKey][]*flowArc)
+	balance := make(map[graph.TKey]graph.TWeight) // Outflow minus inflow
+	for _, edge := range flowEdges {
+		if edge.Flow <= 0 {
+			continue
+		}
+		arc := &flowArc{key: edge.EdgeKey, from: edge.Source, to: edge.Destination, amount: edge.Flow}
+		arcs = append(arcs, arc)
+		outgoing[arc.from] = append(outgoing[arc.from], arc)
+		balance[arc.from] += arc.amount
+		balance[arc.to] -= arc.amount
+	}
+
+	isSink := make(map[graph.TKey]bool, len(sinks))
+	for _, sink := range sinks {
+		isSink[sink] = true
+	}
+
+	decomposition := &FlowDecomposition{Paths: []FlowPath{}, Cycles: []FlowPath{}}
+
+	// nextArc returns an arc still carrying flow out of vertex, dropping emptied ones
+	nextArc := func(vertex graph.TKey) *flowArc {
+		list := outgoing[vertex]
+		for len(list) > 0 && list[0].amount == 0 {
+			list = list[1:]
+		}
+		outgoing[vertex] = list
+		if len(list) == 0 {
+			return nil
+		}
+		return list[0]
+	}
+
+	// Step 1: Paths, while some source still sends flow out
+	for _, source := range sources {
+		for balance[source] > 0 {
+			walk := []*flowArc{}
+			position := map[graph.TKey]int{source: 0}
+			current := source
+
+			for !isSink[current] || balance[current] >= 0 {
+				arc := nextArc(current)
+				if arc == nil {
+					break // Flow is not conserved, nothing more to follow
+				}
+				walk = append(walk, arc)
+				current = arc.to
+
+				// Came back to the walk: cut off the cycle and go on from there
+				if start, seen := position[current]; seen {
+					decomposition.Cycles = append(decomposition.Cycles, takeFlowCycle(walk[start:]))
+					for _, arc := range walk[start:] {
+						if arc.from != current {
+							delete(position, arc.from)
+						}
+					}
+					walk = walk[:start]
+					continue
+				}
+				position[current] = len(walk)
+			}
+
+			if !isSink[current] || current == source {
+				break
+			}
+
+			amount := min(balance[source], -balance[current])
+			for _, arc := range walk {
+				amount = min(amount, arc.amount)
+			}
+			path := FlowPath{Vertices: []graph.TKey{source}, Edges: []graph.TKey{}, Amount: amount}
+			for _, arc := range walk {
+				arc.amount -= amount
+				path.Vertices = append(path.Vertices, arc.to)
+				path.Edges = append(path.Edges, arc.key)
+			}
+			balance[source] -= amount
+			balance[current] += amount
+			decomposition.Paths = append(decomposition.Paths, path)
+		}
+	}
+
+	// Step 2: Whatever is left goes around in cycles
+	for _, arc := range arcs {
+		for arc.amount > 0 {
+			walk := []*flowArc{arc}
+			position := map[graph.TKey]int{arc.from: 0, arc.to: 1}
+
+			for current := arc.to; ; {
+				next := nextArc(current)
+				if next == nil {
+					arc.amount = 0 // Flow is not conserved, give up on this edge
+					break
+				}
+				walk = append(walk, next)
+				current = next.to
+
+				if start, seen := position[current]; seen {
+					decomposition.Cycles = append(decomposition.Cycles, takeFlowCycle(walk[start:]))
+					break
+				}
+				position[current] = len(walk)
+			}
+		}
+	}
+
+	decomposition.Message = fmt.Sprintf("Flow decomposed into %d path(s) and %d cycle(s)",
+		len(decomposition.Paths), len(decomposition.Cycles))
+	return decomposition
+}
+
+// takeFlowCycle subtracts the smallest flow of cycle arcs from all of them and describes the cycle
+func takeFlowCycle(cycle []*flowArc) FlowPath {
+	amount := cycle[0].amount
+	for _, arc := range cycle {
+		amount = min(amount, arc.amount)
+	}
+
+	result := FlowPath{Vertices: []graph.TKey{}, Edges: []graph.TKey{}, Amount: amount}
+	for _, arc := range cycle {
+		arc.amount -= amount
+		result.Vertices = append(result.Vertices, arc.from)
+		result.Edges = append(result.Edges, arc.key)
+	}
+	return result
+}
+
+// ToJSON exports max flow result with its decomposition
+func (result *MaxFlowResult) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FormatFlowDecomposition creates a formatted string representation
+func (decomposition *FlowDecomposition) FormatFlowDecomposition() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("FLOW PATHS (%d):\n", len(decomposition.Paths)))
+	for i, path := range decomposition.Paths {
+		sb.WriteString(fmt.Sprintf("%d. %s  amount %d, edges %s\n",
+			i+1, formatTrail(path.Vertices), path.Amount, formatKeyList(path.Edges)))
+	}
+
+	if len(decomposition.Cycles) > 0 {
+		sb.WriteString(fmt.Sprintf("\nFLOW CYCLES (%d):\n", len(decomposition.Cycles)))
+		for i, cycle := range decomposition.Cycles {
+			vertices := append(slices.Clone(cycle.Vertices), cycle.Vertices[0])
+			sb.WriteString(fmt.Sprintf("%d. %s  amount %d, edges %s\n",
+				i+1, formatTrail(vertices), cycle.Amount, formatKeyList(cycle.Edges)))
+		}
+	}
+
+	return sb.String()
+}
@@ -20,18 +20,31 @@ import (
  * Can handle negative weights but not negative cycles
  */
 
-// AllPairsShortestPath represents the result of Floyd-Warshall algorithm
+// AllPairsShortestPath represents the result of Floyd-Warshall or Johnson's algorithm
+// Floyd-Warshall fills Distances for every pair, unreachable ones with 1 << 30,
+// Johnson's algorithm keeps only reachable pairs. Use Distance to read both
 type AllPairsShortestPath struct {
 	Distances map[graph.TKey]map[graph.TKey]int64      // Shortest distance between every pair
 	Next      map[graph.TKey]map[graph.TKey]graph.TKey // Next vertex in shortest path
 	IsValid   bool                                     // Whether result is valid (no negative cycles)
 	Message   string                                   // Status message about computation
+	Algorithm string                                   // Name of algorithm that computed the result
+	dense     bool                                     // Every pair is in Distances, unreachable ones with apspInfinity
 }
 
-// FindAllPairsShortestPath finds shortest paths between all vertex pairs using Floyd-Warshall
-// Time Complexity: O(V^3) where V is number of vertices
+// FindAllPairsShortestPath finds shortest paths between all vertex pairs
+// Picks Johnson's algorithm for sparse graphs and Floyd-Warshall for dense ones
 // Can handle: directed/undirected graphs, negative weights (but not negative cycles)
 func FindAllPairsShortestPath(gr *graph.Graph) (*AllPairsShortestPath, error) {
+	if gr.Nodes != nil && isSparseGraph(gr) {
+		return FindAllPairsShortestPathJohnson(gr)
+	}
+	return FindAllPairsShortestPathFloyd(gr)
+}
+
+// FindAllPairsShortestPathFloyd finds shortest paths between all vertex pairs using Floyd-Warshall
+// Time Complexity: O(V^3) where V is number of vertices
+func FindAllPairsShortestPathFloyd(gr *graph.Graph) (*AllPairsShortestPath, error) {
 	if gr.Nodes == nil {
 		return nil, graph.ThrowNodesListIsNil()
 	}
@@ -43,12 +56,16 @@ func FindAllPairsShortestPath(gr *graph.Graph) (*AllPairsShortestPath, error) {
 			Next:      make(map[graph.TKey]map[graph.TKey]graph.TKey),
 			IsValid:   true,
 			Message:   "Graph is empty",
+			Algorithm: "Floyd-Warshall",
 		}, nil
 	}
 
 	return findFloydWarshall(gr)
 }
 
+// apspInfinity marks unreachable pairs in distance matrix
+const apspInfinity = int64(1 << 30)
+
 // findFloydWarshall implements the core Floyd-Warshall algorithm
 // Algorithm Strategy: Dynamic Programming - gradually improve shortest path estimates
 // by considering each vertex as an intermediate point
@@ -63,7 +80,7 @@ func findFloydWarshall(gr *graph.Graph) (*AllPairsShortestPath, error) {
 	dist := make(map[graph.TKey]map[graph.TKey]int64)
 	next := make(map[graph.TKey]map[graph.TKey]graph.TKey)
 
-	infinity := int64(1 << 30)
+	infinity := apspInfinity
 
 	// Step 3: Initialize matrices with base cases
 	for _, i := range keys {
@@ -126,8 +143,9 @@ func findFloydWarshall(gr *graph.Graph) (*AllPairsShortestPath, error) {
 	for _, k := range keys {
 		if dist[k][k] < 0 {
 			return &AllPairsShortestPath{
-				IsValid: false,
-				Message: "Graph contains negative weight cycles",
+				IsValid:   false,
+				Message:   "Graph contains negative weight cycles",
+				Algorithm: "Floyd-Warshall",
 			}, nil
 		}
 	}
@@ -138,9 +156,22 @@ func findFloydWarshall(gr *graph.Graph) (*AllPairsShortestPath, error) {
 		Next:      next,
 		IsValid:   true,
 		Message:   fmt.Sprintf("Computed shortest paths for %d vertices", len(keys)),
+		Algorithm: "Floyd-Warshall",
+		dense:     true,
 	}, nil
 }
 
+// Distance returns shortest distance between two vertices and whether end is reachable
+// Johnson's algorithm keeps only reachable pairs, so missing entries mean infinity too,
+// and its distances may exceed 1 << 30 which marks infinity only in Floyd-Warshall matrix
+func (apsp *AllPairsShortestPath) Distance(start, end graph.TKey) (int64, bool) {
+	dist, exists := apsp.Distances[start][end]
+	if !exists || apsp.dense && dist >= apspInfinity {
+		return apspInfinity, false
+	}
+	return dist, true
+}
+
 // GetPath reconstructs the shortest path from start to end using the next matrix
 // Returns the sequence of vertices in the shortest path
 func (apsp *AllPairsShortestPath) GetPath(start, end graph.TKey) []graph.TKey {
@@ -173,7 +204,7 @@ func (apsp *AllPairsShortestPath) FormatDistanceMatrix(gr *graph.Graph) string {
 
 	// Header information
 	sb.WriteString("SHORTEST PATH DISTANCES BETWEEN ALL PAIRS OF VERTICES\n\n")
-	sb.WriteString("Algorithm: Floyd-Warshall\n")
+	sb.WriteString(fmt.Sprintf("Algorithm: %s\n", apsp.Algorithm))
 	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(keys)))
 	sb.WriteString(fmt.Sprintf("Total edges: %d\n", len(gr.Edges)))
 	sb.WriteString(fmt.Sprintf("Directed: %v\n\n", gr.Options.IsDirected))
@@ -194,7 +225,6 @@ func (apsp *AllPairsShortestPath) FormatDistanceMatrix(gr *graph.Graph) string {
 	sb.WriteString(strings.Repeat("─", 8+len(keys)*12) + "\n")
 
 	// Distance matrix rows
-	infinity := int64(1 << 30)
 	for _, i := range keys {
 		node, _ := gr.GetNodeByKey(i)
 		if node != nil && node.Label != "" {
@@ -205,8 +235,8 @@ func (apsp *AllPairsShortestPath) FormatDistanceMatrix(gr *graph.Graph) string {
 
 		// Distance values for this row
 		for _, j := range keys {
-			dist := apsp.Distances[i][j]
-			if dist == infinity {
+			dist, reachable := apsp.Distance(i, j)
+			if !reachable {
 				sb.WriteString(fmt.Sprintf("%-12s", "inf"))
 			} else if i == j {
 				sb.WriteString(fmt.Sprintf("%-12s", "0"))
@@ -226,7 +256,7 @@ func (apsp *AllPairsShortestPath) FormatDistanceMatrix(gr *graph.Graph) string {
 
 	for _, i := range keys {
 		for _, j := range keys {
-			if i != j && apsp.Distances[i][j] < infinity {
+			if _, reachable := apsp.Distance(i, j); i != j && reachable {
 				reachablePairs++
 			}
 		}
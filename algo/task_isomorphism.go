@@ -0,0 +1,440 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Check if graphs are isomorphic and find pattern graph inside target
+ *
+ * Mapping sends every pattern node to its own target node so that edges are
+ * kept:
+ *   - Isomorphism: mapping is a bijection and every pair of nodes has the same
+ *     number of edges in both graphs
+ *   - Induced subgraph: pattern is equal to the subgraph induced by the image,
+ *     so missing edges must be missing in target too
+ *   - Subgraph (monomorphism): edges of pattern must exist in target, target
+ *     may have extra edges between image nodes
+ *
+ * VF2 Algorithm - depth-first search extends partial mapping by one pair at a
+ * time and drops pairs that cannot lead to a full mapping:
+ *   - pair must pass node predicate and degree check
+ *   - edges to already mapped nodes must correspond
+ *   - look-ahead: u must not have more unmapped neighbors than its image
+ *
+ * Pattern nodes are matched in VF2++ order: next node is the one with most
+ * already ordered neighbors (then the largest degree), so candidates for it
+ * are only neighbors of the image of its ordered neighbor, not all nodes.
+ *
+ * Node and edge predicates allow matching labels or weights. Parallel edges
+ * between a pair are matched to each other with bipartite matching.
+ * Exponential in worst case, but fast for most real graphs.
+ */
+
+// DefaultMaxMappings is the default limit of listed mappings
+const DefaultMaxMappings = 100
+
+// MatchOptions configures isomorphism and subgraph matching
+type MatchOptions struct {
+	NodeMatch   func(patternNode, targetNode *graph.Node) bool // Nodes may be mapped, nil matches everything
+	EdgeMatch   func(patternEdge, targetEdge *graph.Edge) bool // Edges may correspond, nil matches everything
+	Induced     bool                                           // Subgraph matching keeps missing edges missing
+	MaxMappings int                                            // Stop after so many mappings, 0 for no limit
+}
+
+func WithNodeMatch(match func(patternNode, targetNode *graph.Node) bool) graph.Option[MatchOptions] {
+	return func(opts *MatchOptions) {
+		opts.NodeMatch = match
+	}
+}
+
+func WithEdgeMatch(match func(patternEdge, targetEdge *graph.Edge) bool) graph.Option[MatchOptions] {
+	return func(opts *MatchOptions) {
+		opts.EdgeMatch = match
+	}
+}
+
+func WithInduced(induced bool) graph.Option[MatchOptions] {
+	return func(opts *MatchOptions) {
+		opts.Induced = induced
+	}
+}
+
+func WithMaxMappings(limit int) graph.Option[MatchOptions] {
+	return func(opts *MatchOptions) {
+		opts.MaxMappings = limit
+	}
+}
+
+// MatchNodeLabels is node predicate requiring equal labels
+func MatchNodeLabels(patternNode, targetNode *graph.Node) bool {
+	return patternNode.Label == targetNode.Label
+}
+
+// MatchEdgeLabels is edge predicate requiring equal labels
+func MatchEdgeLabels(patternEdge, targetEdge *graph.Edge) bool {
+	return patternEdge.Label == targetEdge.Label
+}
+
+// MatchEdgeWeights is edge predicate requiring equal weights
+func MatchEdgeWeights(patternEdge, targetEdge *graph.Edge) bool {
+	return patternEdge.Weight == targetEdge.Weight
+}
+
+// IsomorphismResult represents mappings of pattern nodes to target nodes
+type IsomorphismResult struct {
+	Mappings  []map[graph.TKey]graph.TKey // Found mappings, pattern node to target node
+	Found     bool                        // At least one mapping exists
+	Truncated bool                        // More mappings exist than the limit allows to list
+	Kind      string                      // Isomorphism, induced subgraph or subgraph
+	Message   string                      // Status message
+}
+
+// matchGraph is graph on indices prepared for matching
+type matchGraph struct {
+	keys      []graph.TKey
+	nodes     []*graph.Node
+	neighbors [][]int                  // Distinct neighbors in both directions, without the node itself
+	edges     map[[2]int][]*graph.Edge // Edges of every ordered pair, undirected pairs are stored as (min, max)
+	directed  bool
+}
+
+// buildMatchGraph converts graph to indices
+func buildMatchGraph(gr *graph.Graph) (*matchGraph, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	mg := &matchGraph{
+		keys:     getSortedKeys(gr.Nodes),
+		edges:    make(map[[2]int][]*graph.Edge),
+		directed: gr.Options.IsDirected,
+	}
+	index := make(map[graph.TKey]int, len(mg.keys))
+	mg.nodes = make([]*graph.Node, len(mg.keys))
+	for i, key := range mg.keys {
+		index[key] = i
+		mg.nodes[i] = gr.Nodes[key]
+	}
+
+	mg.neighbors = make([][]int, len(mg.keys))
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		u, v := index[edge.Source], index[edge.Destination]
+		pair := mg.pair(u, v)
+		mg.edges[pair] = append(mg.edges[pair], edge)
+		if u != v {
+			mg.neighbors[u] = append(mg.neighbors[u], v)
+			mg.neighbors[v] = append(mg.neighbors[v], u)
+		}
+	}
+	for v := range mg.neighbors {
+		slices.Sort(mg.neighbors[v])
+		mg.neighbors[v] = slices.Compact(mg.neighbors[v])
+	}
+	return mg, nil
+}
+
+// pair returns key of edges from u to v
+func (mg *matchGraph) pair(u, v int) [2]int {
+	if !mg.directed && u > v {
+		u, v = v, u
+	}
+	return [2]int{u, v}
+}
+
+// between returns edges from u to v
+func (mg *matchGraph) between(u, v int) []*graph.Edge {
+	return mg.edges[mg.pair(u, v)]
+}
+
+// FindIsomorphisms finds mappings showing that pattern and target graphs are isomorphic
+func FindIsomorphisms(pattern, target *graph.Graph, options ...graph.Option[MatchOptions]) (*IsomorphismResult, error) {
+	return findMappings(pattern, target, "Isomorphism", options)
+}
+
+// FindSubgraphMatches finds occurrences of pattern graph inside target graph
+func FindSubgraphMatches(pattern, target *graph.Graph, options ...graph.Option[MatchOptions]) (*IsomorphismResult, error) {
+	return findMappings(pattern, target, "", options)
+}
+
+// findMappings runs VF2 search, kind is empty for subgraph matching
+func findMappings(pattern, target *graph.Graph, kind string, options []graph.Option[MatchOptions]) (*IsomorphismResult, error) {
+	opts := MatchOptions{Induced: true, MaxMappings: DefaultMaxMappings}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	p, err := buildMatchGraph(pattern)
+	if err != nil {
+		return nil, err
+	}
+	t, err := buildMatchGraph(target)
+	if err != nil {
+		return nil, err
+	}
+	if p.directed != t.directed {
+		return nil, fmt.Errorf("pattern and target must be both directed or both undirected")
+	}
+
+	isomorphism := kind != ""
+	exact := isomorphism || opts.Induced
+	if !isomorphism {
+		kind = "Subgraph"
+		if opts.Induced {
+			kind = "Induced subgraph"
+		}
+	}
+
+	result := &IsomorphismResult{Mappings: []map[graph.TKey]graph.TKey{}, Kind: kind}
+	if isomorphism && !sameDegrees(p, t, len(pattern.Edges), len(target.Edges)) {
+		result.Message = "Graphs are not isomorphic: sizes or degrees differ"
+		return result, nil
+	}
+	if len(p.keys) > len(t.keys) {
+		result.Message = "Pattern has more nodes than target"
+		return result, nil
+	}
+
+	order := matchingOrder(p)
+	parent := make([]int, len(order)) // Earlier ordered neighbor of every ordered node, or -1
+	position := make([]int, len(order))
+	for i, u := range order {
+		position[u] = i
+	}
+	for i, u := range order {
+		parent[i] = -1
+		for _, w := range p.neighbors[u] {
+			if position[w] < i && (parent[i] == -1 || position[w] < position[parent[i]]) {
+				parent[i] = w
+			}
+		}
+	}
+
+	core1 := make([]int, len(p.keys)) // Image of pattern node, or -1
+	core2 := make([]int, len(t.keys)) // Preimage of target node, or -1
+	for i := range core1 {
+		core1[i] = -1
+	}
+	for i := range core2 {
+		core2[i] = -1
+	}
+
+	// unmappedNeighbors counts neighbors without a pair
+	unmappedNeighbors := func(mg *matchGraph, core []int, v int) int {
+		count := 0
+		for _, w := range mg.neighbors[v] {
+			if core[w] == -1 {
+				count++
+			}
+		}
+		return count
+	}
+
+	feasible := func(u, x int) bool {
+		if opts.NodeMatch != nil && !opts.NodeMatch(p.nodes[u], t.nodes[x]) {
+			return false
+		}
+		if isomorphism && len(p.neighbors[u]) != len(t.neighbors[x]) || len(p.neighbors[u]) > len(t.neighbors[x]) {
+			return false
+		}
+		if !edgesCorrespond(p.between(u, u), t.between(x, x), exact, opts.EdgeMatch) {
+			return false
+		}
+
+		for _, w := range p.neighbors[u] {
+			y := core1[w]
+			if y == -1 {
+				continue
+			}
+			if !edgesCorrespond(p.between(u, w), t.between(x, y), exact, opts.EdgeMatch) {
+				return false
+			}
+			if p.directed && !edgesCorrespond(p.between(w, u), t.between(y, x), exact, opts.EdgeMatch) {
+				return false
+			}
+		}
+		if exact {
+			// Mapped target neighbors must come from pattern neighbors
+			for _, y := range t.neighbors[x] {
+				if w := core2[y]; w != -1 && len(p.between(u, w)) == 0 && len(p.between(w, u)) == 0 {
+					return false
+				}
+			}
+		}
+
+		pu, tx := unmappedNeighbors(p, core1, u), unmappedNeighbors(t, core2, x)
+		return pu == tx || !isomorphism && pu < tx
+	}
+
+	var search func(depth int) bool
+	search = func(depth int) bool {
+		if depth == len(order) {
+			// One mapping over the limit proves there are more, it is not listed
+			if opts.MaxMappings > 0 && len(result.Mappings) >= opts.MaxMappings {
+				return true
+			}
+			mapping := make(map[graph.TKey]graph.TKey, len(order))
+			for u, x := range core1 {
+				mapping[p.keys[u]] = t.keys[x]
+			}
+			result.Mappings = append(result.Mappings, mapping)
+			return false
+		}
+
+		u := order[depth]
+		var candidates []int
+		if parent[depth] != -1 {
+			candidates = t.neighbors[core1[parent[depth]]]
+		} else {
+			candidates = make([]int, len(t.keys))
+			for i := range candidates {
+				candidates[i] = i
+			}
+		}
+
+		for _, x := range candidates {
+			if core2[x] != -1 || !feasible(u, x) {
+				continue
+			}
+			core1[u], core2[x] = x, u
+			stop := search(depth + 1)
+			core1[u], core2[x] = -1, -1
+			if stop {
+				return true
+			}
+		}
+		return false
+	}
+	result.Truncated = search(0)
+	result.Found = len(result.Mappings) > 0
+
+	switch {
+	case !result.Found && isomorphism:
+		result.Message = "Graphs are not isomorphic"
+	case !result.Found:
+		result.Message = fmt.Sprintf("%s not found", kind)
+	case result.Truncated:
+		result.Message = fmt.Sprintf("%s: found first %d mappings", kind, len(result.Mappings))
+	default:
+		result.Message = fmt.Sprintf("%s: found %d mappings", kind, len(result.Mappings))
+	}
+	return result, nil
+}
+
+// sameDegrees checks sizes and sorted degree sequences, necessary for isomorphism
+func sameDegrees(p, t *matchGraph, patternEdges, targetEdges int) bool {
+	if len(p.keys) != len(t.keys) || patternEdges != targetEdges {
+		return false
+	}
+	degrees := func(mg *matchGraph) []int {
+		result := make([]int, len(mg.neighbors))
+		for v := range mg.neighbors {
+			result[v] = len(mg.neighbors[v])
+		}
+		slices.Sort(result)
+		return result
+	}
+	return slices.Equal(degrees(p), degrees(t))
+}
+
+// matchingOrder orders pattern nodes: most already ordered neighbors first, then largest degree
+func matchingOrder(p *matchGraph) []int {
+	n := len(p.keys)
+	order := make([]int, 0, n)
+	ordered := make([]bool, n)
+	connections := make([]int, n)
+
+	for len(order) < n {
+		best := -1
+		for v := range n {
+			if ordered[v] {
+				continue
+			}
+			if best == -1 || connections[v] > connections[best] ||
+				connections[v] == connections[best] && len(p.neighbors[v]) > len(p.neighbors[best]) {
+				best = v
+			}
+		}
+		order = append(order, best)
+		ordered[best] = true
+		for _, w := range p.neighbors[best] {
+			connections[w]++
+		}
+	}
+	return order
+}
+
+// edgesCorrespond checks if pattern edges of a pair can be matched to distinct target edges of the image pair
+func edgesCorrespond(patternEdges, targetEdges []*graph.Edge, exact bool, match func(patternEdge, targetEdge *graph.Edge) bool) bool {
+	if exact && len(patternEdges) != len(targetEdges) || len(patternEdges) > len(targetEdges) {
+		return false
+	}
+	if match == nil || len(patternEdges) == 0 {
+		return true
+	}
+
+	// Kuhn's augmenting paths, lists are short
+	owner := make([]int, len(targetEdges))
+	for i := range owner {
+		owner[i] = -1
+	}
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for j, edge := range targetEdges {
+			if visited[j] || !match(patternEdges[i], edge) {
+				continue
+			}
+			visited[j] = true
+			if owner[j] == -1 || augment(owner[j], visited) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range patternEdges {
+		if !augment(i, make([]bool, len(targetEdges))) {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatIsomorphismResult creates a formatted string representation
+func (result *IsomorphismResult) FormatIsomorphismResult(pattern, target *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s MATCHING\n\n", strings.ToUpper(result.Kind)))
+	sb.WriteString(fmt.Sprintf("Pattern: %d nodes, %d edges\n", len(pattern.Nodes), len(pattern.Edges)))
+	sb.WriteString(fmt.Sprintf("Target: %d nodes, %d edges\n", len(target.Nodes), len(target.Edges)))
+	sb.WriteString(fmt.Sprintf("Mappings found: %d", len(result.Mappings)))
+	if result.Truncated {
+		sb.WriteString(" (limit reached, there are more)")
+	}
+	sb.WriteString("\n\n")
+
+	if !result.Found {
+		sb.WriteString(result.Message + "\n")
+		return sb.String()
+	}
+
+	for i, mapping := range result.Mappings {
+		sb.WriteString(fmt.Sprintf("Mapping %d\n", i+1))
+		sb.WriteString(strings.Repeat("─", 50) + "\n")
+		for _, key := range getSortedMapKeys(mapping) {
+			sb.WriteString(fmt.Sprintf("  %s → %s\n", formatNodeName(pattern, key), formatNodeName(target, mapping[key])))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
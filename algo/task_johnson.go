@@ -0,0 +1,99 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find shortest paths between all pairs of vertices using Johnson's algorithm
+ *
+ * Johnson's Algorithm - run Dijkstra from every vertex. Negative weights are
+ * removed first by reweighting: w'(u, v) = w(u, v) + h(u) - h(v), where h is
+ * a distance from virtual vertex connected to everything by zero edges (found
+ * by Bellman-Ford). Any path u → v changes by h(u) - h(v), so shortest paths
+ * stay the same, and all new weights are non-negative.
+ *
+ * Time Complexity: O(V * E log V), much better than O(V^3) for sparse graphs.
+ * Only reachable pairs are stored, so memory is O(V + number of reachable pairs).
+ */
+
+// FindAllPairsShortestPathJohnson finds shortest paths between all vertex pairs using Johnson's algorithm
+func FindAllPairsShortestPathJohnson(gr *graph.Graph) (*AllPairsShortestPath, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	arcs := buildArcLists(gr)
+
+	// Step 1: Potentials from virtual source, or a negative cycle
+	potential, _, hasCycle := bellmanFordSuperSource(keys, arcs)
+	if hasCycle {
+		return &AllPairsShortestPath{
+			IsValid:   false,
+			Message:   "Graph contains negative weight cycles",
+			Algorithm: "Johnson",
+		}, nil
+	}
+
+	// Step 2: Reweight every arc, making all of them non-negative
+	reweighted := make(map[graph.TKey][]weightedArc, len(arcs))
+	for u, list := range arcs {
+		reweighted[u] = make([]weightedArc, len(list))
+		for i, arc := range list {
+			arc.weight += potential[u] - potential[arc.to]
+			reweighted[u][i] = arc
+		}
+	}
+
+	// Step 3: Dijkstra from every vertex, restoring real distances
+	dist := make(map[graph.TKey]map[graph.TKey]int64, len(keys))
+	next := make(map[graph.TKey]map[graph.TKey]graph.TKey, len(keys))
+
+	for _, source := range keys {
+		tree := dijkstraHeap(reweighted, source)
+
+		dist[source] = make(map[graph.TKey]int64, len(tree.dist))
+		next[source] = make(map[graph.TKey]graph.TKey, len(tree.dist))
+
+		// Vertices come in settle order, so the next hop of predecessor is known
+		for _, vertex := range tree.order {
+			dist[source][vertex] = tree.dist[vertex] - potential[source] + potential[vertex]
+			if vertex == source {
+				continue
+			}
+			if from := tree.prev[vertex].from; from == source {
+				next[source][vertex] = vertex
+			} else {
+				next[source][vertex] = next[source][from]
+			}
+		}
+	}
+
+	return &AllPairsShortestPath{
+		Distances: dist,
+		Next:      next,
+		IsValid:   true,
+		Message:   fmt.Sprintf("Computed shortest paths for %d vertices", len(keys)),
+		Algorithm: "Johnson",
+	}, nil
+}
+
+// isSparseGraph decides if Johnson's O(V * E log V) beats Floyd-Warshall's O(V^3)
+func isSparseGraph(gr *graph.Graph) bool {
+	nodes := len(gr.Nodes)
+	arcs := len(gr.Edges)
+	if !gr.Options.IsDirected {
+		arcs *= 2
+	}
+
+	logV := bits.Len(uint(nodes))
+	return arcs*logV < nodes*nodes
+}
@@ -0,0 +1,393 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find k shortest loopless paths and all shortest paths between nodes
+ *
+ * Yen's Algorithm - k shortest loopless paths in increasing order of weight:
+ *   1. The first path is found by Dijkstra
+ *   2. For path k, every node of path k - 1 is tried as spur node: the root
+ *      part before it is kept, edges that leave the same root in already found
+ *      paths are removed together with root nodes, and Dijkstra finds the spur
+ *      part from spur node to target
+ *   3. Root + spur paths are candidates, the lightest becomes path k
+ * O(K * V * E log V). Parallel edges give different paths.
+ *
+ * Shortest Path DAG - arc u → v lies on a shortest path from source iff
+ * dist(u) + w(u, v) = dist(v), and every source-target path of such arcs is a
+ * shortest path. Positive arcs always lead to bigger distance, but zero weight
+ * arcs join nodes at equal distance and may form cycles. DFS over zero weight
+ * tight arcs drops only arcs into nodes still on DFS stack, I.e. arcs closing
+ * a zero weight cycle; without zero cycles nothing is lost. Nodes are ordered
+ * by distance, and equal distances by DFS finish time (topological order of
+ * zero arcs). Number of shortest paths is counted by dynamic programming in
+ * that order:
+ *   count(source) = 1, count(v) = Σ count(u) over DAG arcs u → v.
+ * Counts grow exponentially, so they are big integers.
+ */
+
+// WeightedPath is a path with its vertices, edge keys and total weight
+type WeightedPath struct {
+	Vertices []graph.TKey // Vertices from source to target
+	Edges    []graph.TKey // Keys of edges between consecutive vertices
+	Weight   int64        // Sum of edge weights
+}
+
+// KShortestPathsResult represents k shortest loopless paths between two nodes
+type KShortestPathsResult struct {
+	Source  graph.TKey     // Start node
+	Target  graph.TKey     // End node
+	Paths   []WeightedPath // Paths in increasing order of weight, fewer than k if no more exist
+	Message string         // Status message
+}
+
+// ShortestPathDAGResult represents all shortest paths from source, and DAG of those leading to target
+type ShortestPathDAGResult struct {
+	Source    graph.TKey              // Start node
+	Target    graph.TKey              // End node
+	Distances map[graph.TKey]int64    // Distances to reached nodes
+	Counts    map[graph.TKey]*big.Int // Number of shortest paths to every reached node
+	DAG       *graph.Graph            // Directed graph of edges lying on shortest paths to target
+	Message   string                  // Status message
+}
+
+// validatePathEnds checks graph, both nodes and weights
+func validatePathEnds(gr *graph.Graph, source, target graph.TKey) error {
+	if err := validateSingleSource(gr, source); err != nil {
+		return err
+	}
+	if _, err := gr.GetNodeByKey(target); err != nil {
+		return fmt.Errorf("target node %d does not exist", target)
+	}
+	return checkNonNegativeWeights(gr)
+}
+
+// FindKShortestPaths finds up to k shortest loopless paths from source to target using Yen's algorithm
+func FindKShortestPaths(gr *graph.Graph, source, target graph.TKey, k int) (*KShortestPathsResult, error) {
+	if err := validatePathEnds(gr, source, target); err != nil {
+		return nil, err
+	}
+	if k < 1 {
+		return nil, fmt.Errorf("number of paths must be positive, got %d", k)
+	}
+
+	result := &KShortestPathsResult{Source: source, Target: target, Paths: []WeightedPath{}}
+	arcs := buildArcLists(gr)
+	vertices, edges := dijkstraHeap(arcs, source).pathTo(target)
+	if vertices == nil {
+		result.Message = fmt.Sprintf("Node %d is not reachable from %d", target, source)
+		return result, nil
+	}
+	result.Paths = append(result.Paths, makeWeightedPath(gr, vertices, edges))
+
+	candidates := []WeightedPath{}
+	seen := map[string]bool{pathSignature(result.Paths[0]): true}
+	for len(result.Paths) < k {
+		last := result.Paths[len(result.Paths)-1]
+		for i := 0; i+1 < len(last.Vertices); i++ {
+			spur := last.Vertices[i]
+			rootVertices, rootEdges := last.Vertices[:i+1], last.Edges[:i]
+
+			// Forbid edges continuing this root in found paths, and root nodes before spur
+			bannedEdges := make(map[graph.TKey]bool)
+			for _, path := range result.Paths {
+				if len(path.Edges) > i && slices.Equal(path.Vertices[:i+1], rootVertices) && slices.Equal(path.Edges[:i], rootEdges) {
+					bannedEdges[path.Edges[i]] = true
+				}
+			}
+			bannedNodes := make(map[graph.TKey]bool, i)
+			for _, vertex := range rootVertices[:i] {
+				bannedNodes[vertex] = true
+			}
+
+			filtered := make(map[graph.TKey][]weightedArc, len(arcs))
+			for from, list := range arcs {
+				if bannedNodes[from] {
+					continue
+				}
+				for _, arc := range list {
+					if !bannedNodes[arc.to] && !bannedEdges[arc.key] {
+						filtered[from] = append(filtered[from], arc)
+					}
+				}
+			}
+
+			spurVertices, spurEdges := dijkstraHeap(filtered, spur).pathTo(target)
+			if spurVertices == nil {
+				continue
+			}
+			candidate := makeWeightedPath(gr,
+				append(slices.Clone(rootVertices), spurVertices[1:]...),
+				append(slices.Clone(rootEdges), spurEdges...))
+			if signature := pathSignature(candidate); !seen[signature] {
+				seen[signature] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		best := 0
+		for i := range candidates {
+			if lighterPath(candidates[i], candidates[best]) {
+				best = i
+			}
+		}
+		result.Paths = append(result.Paths, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+
+	result.Message = fmt.Sprintf("Found %d shortest paths from %d to %d", len(result.Paths), source, target)
+	return result, nil
+}
+
+// makeWeightedPath sums weights of path edges
+func makeWeightedPath(gr *graph.Graph, vertices, edges []graph.TKey) WeightedPath {
+	path := WeightedPath{Vertices: vertices, Edges: edges}
+	for _, key := range edges {
+		path.Weight += int64(gr.Edges[key].Weight)
+	}
+	return path
+}
+
+// pathSignature identifies path by its vertices and edges
+func pathSignature(path WeightedPath) string {
+	return fmt.Sprint(path.Vertices, path.Edges)
+}
+
+// lighterPath orders paths by weight, then by number of edges, then by edge keys
+func lighterPath(a, b WeightedPath) bool {
+	if a.Weight != b.Weight {
+		return a.Weight < b.Weight
+	}
+	if len(a.Edges) != len(b.Edges) {
+		return len(a.Edges) < len(b.Edges)
+	}
+	return slices.Compare(a.Edges, b.Edges) < 0
+}
+
+// FindShortestPathDAG counts shortest paths from source and builds DAG of all shortest paths to target
+func FindShortestPathDAG(gr *graph.Graph, source, target graph.TKey) (*ShortestPathDAGResult, error) {
+	if err := validatePathEnds(gr, source, target); err != nil {
+		return nil, err
+	}
+
+	arcs := buildArcLists(gr)
+	tree := dijkstraHeap(arcs, source)
+
+	// Positive tight arcs lead to bigger distance, so they never make a cycle
+	tight := make(map[graph.TKey][]weightedArc)
+	for _, from := range tree.order {
+		for _, arc := range arcs[from] {
+			if arc.weight > 0 && tree.dist[from]+arc.weight == tree.dist[arc.to] {
+				tight[from] = append(tight[from], arc)
+			}
+		}
+	}
+
+	// Zero tight arcs: DFS drops arcs into nodes on stack, they close zero cycles
+	order := slices.Clone(tree.order)
+	slices.SortFunc(order, func(a, b graph.TKey) int {
+		return cmp.Or(cmp.Compare(tree.dist[a], tree.dist[b]), cmp.Compare(a, b))
+	})
+	onStack := make(map[graph.TKey]bool)
+	finish := make(map[graph.TKey]int)
+	var visit func(vertex graph.TKey)
+	visit = func(vertex graph.TKey) {
+		onStack[vertex] = true
+		for _, arc := range arcs[vertex] {
+			if arc.weight != 0 || tree.dist[arc.to] != tree.dist[vertex] || onStack[arc.to] {
+				continue
+			}
+			tight[vertex] = append(tight[vertex], arc)
+			if _, done := finish[arc.to]; !done {
+				visit(arc.to)
+			}
+		}
+		onStack[vertex] = false
+		finish[vertex] = len(finish)
+	}
+	for _, vertex := range order {
+		if _, done := finish[vertex]; !done {
+			visit(vertex)
+		}
+	}
+
+	// Topological order of tight arcs: by distance, then zero arcs go to earlier finished nodes
+	slices.SortStableFunc(order, func(a, b graph.TKey) int {
+		return cmp.Or(cmp.Compare(tree.dist[a], tree.dist[b]), cmp.Compare(finish[b], finish[a]))
+	})
+
+	result := &ShortestPathDAGResult{
+		Source:    source,
+		Target:    target,
+		Distances: tree.dist,
+		Counts:    make(map[graph.TKey]*big.Int, len(order)),
+		DAG:       graph.MakeGraph(graph.WithGraphDirected(true), graph.WithGraphMulti(gr.Options.IsMulti)),
+	}
+	for _, vertex := range order {
+		result.Counts[vertex] = new(big.Int)
+	}
+	result.Counts[source].SetInt64(1)
+	for _, from := range order {
+		for _, arc := range tight[from] {
+			result.Counts[arc.to].Add(result.Counts[arc.to], result.Counts[from])
+		}
+	}
+
+	// Keep nodes that reach target through tight arcs
+	onPath := make(map[graph.TKey]bool)
+	if _, reached := tree.dist[target]; reached {
+		onPath[target] = true
+		for i := len(order) - 1; i >= 0; i-- {
+			for _, arc := range tight[order[i]] {
+				if onPath[arc.to] {
+					onPath[order[i]] = true
+				}
+			}
+		}
+	}
+	for _, vertex := range order {
+		if onPath[vertex] {
+			result.DAG.AddNode(graph.MakeNode(vertex, graph.WithNodeLabel(gr.Nodes[vertex].Label)))
+		}
+	}
+	for _, from := range order {
+		for _, arc := range tight[from] {
+			if onPath[from] && onPath[arc.to] {
+				edge := gr.Edges[arc.key]
+				if err := result.DAG.AddEdge(graph.MakeEdge(arc.key, from, arc.to,
+					graph.WithEdgeWeight(edge.Weight), graph.WithEdgeLabel(edge.Label))); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if count, reached := result.Counts[target]; reached {
+		result.Message = fmt.Sprintf("Found %s shortest paths of weight %d from %d to %d", count, tree.dist[target], source, target)
+	} else {
+		result.Message = fmt.Sprintf("Node %d is not reachable from %d", target, source)
+	}
+	return result, nil
+}
+
+// CountShortestPaths returns number of shortest paths from source to target, 0 if unreachable
+func CountShortestPaths(gr *graph.Graph, source, target graph.TKey) (*big.Int, error) {
+	result, err := FindShortestPathDAG(gr, source, target)
+	if err != nil {
+		return nil, err
+	}
+	if count, reached := result.Counts[target]; reached {
+		return count, nil
+	}
+	return new(big.Int), nil
+}
+
+// Paths enumerates shortest paths from source to target in the DAG, limit 0 lists all of them
+func (result *ShortestPathDAGResult) Paths(limit int) []WeightedPath {
+	next := make(map[graph.TKey][]*graph.Edge)
+	for _, key := range getSortedMapKeys(result.DAG.Edges) {
+		edge := result.DAG.Edges[key]
+		next[edge.Source] = append(next[edge.Source], edge)
+	}
+
+	paths := []WeightedPath{}
+	if _, ok := result.DAG.Nodes[result.Source]; !ok {
+		return paths
+	}
+	vertices := []graph.TKey{result.Source}
+	edges := []graph.TKey{}
+	var walk func(vertex graph.TKey) bool
+	walk = func(vertex graph.TKey) bool {
+		if vertex == result.Target {
+			paths = append(paths, WeightedPath{
+				Vertices: slices.Clone(vertices),
+				Edges:    slices.Clone(edges),
+				Weight:   result.Distances[result.Target],
+			})
+			return limit > 0 && len(paths) >= limit
+		}
+		for _, edge := range next[vertex] {
+			vertices = append(vertices, edge.Destination)
+			edges = append(edges, edge.Key)
+			stop := walk(edge.Destination)
+			vertices, edges = vertices[:len(vertices)-1], edges[:len(edges)-1]
+			if stop {
+				return true
+			}
+		}
+		return false
+	}
+	walk(result.Source)
+	return paths
+}
+
+// formatWeightedPaths lists paths with their weights
+func formatWeightedPaths(sb *strings.Builder, paths []WeightedPath) {
+	sb.WriteString(fmt.Sprintf("%-6s %-10s %-24s %s\n", "#", "Weight", "Path", "Edges"))
+	sb.WriteString(strings.Repeat("─", 60) + "\n")
+	for i, path := range paths {
+		sb.WriteString(fmt.Sprintf("%-6d %-10d %-24s %s\n", i+1, path.Weight, formatTrail(path.Vertices), formatKeyList(path.Edges)))
+	}
+}
+
+// FormatKShortestPathsResult creates a formatted string representation
+func (result *KShortestPathsResult) FormatKShortestPathsResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("K SHORTEST PATHS (Yen)\n\n")
+	sb.WriteString(fmt.Sprintf("Source: %s\n", formatNodeName(gr, result.Source)))
+	sb.WriteString(fmt.Sprintf("Target: %s\n", formatNodeName(gr, result.Target)))
+	sb.WriteString(fmt.Sprintf("Paths found: %d\n\n", len(result.Paths)))
+	formatWeightedPaths(&sb, result.Paths)
+
+	return sb.String()
+}
+
+// FormatShortestPathDAGResult creates a formatted string representation, listing at most limit paths
+func (result *ShortestPathDAGResult) FormatShortestPathDAGResult(gr *graph.Graph, limit int) string {
+	var sb strings.Builder
+
+	sb.WriteString("ALL SHORTEST PATHS\n\n")
+	sb.WriteString(fmt.Sprintf("Source: %s\n", formatNodeName(gr, result.Source)))
+	sb.WriteString(fmt.Sprintf("Target: %s\n", formatNodeName(gr, result.Target)))
+	count, reached := result.Counts[result.Target]
+	if !reached {
+		sb.WriteString("Target is not reachable\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("Distance: %d\n", result.Distances[result.Target]))
+	sb.WriteString(fmt.Sprintf("Number of shortest paths: %s\n", count))
+	sb.WriteString(fmt.Sprintf("DAG: %d nodes, %d edges\n\n", len(result.DAG.Nodes), len(result.DAG.Edges)))
+
+	sb.WriteString("DAG EDGES\n")
+	sb.WriteString(strings.Repeat("─", 60) + "\n")
+	for _, key := range getSortedMapKeys(result.DAG.Edges) {
+		edge := result.DAG.Edges[key]
+		sb.WriteString(fmt.Sprintf("Edge %d: %d → %d (weight %d)\n", key, edge.Source, edge.Destination, edge.Weight))
+	}
+	sb.WriteString("\n")
+
+	paths := result.Paths(limit)
+	if limit > 0 && count.Cmp(big.NewInt(int64(limit))) > 0 {
+		sb.WriteString(fmt.Sprintf("First %d paths:\n", limit))
+	}
+	formatWeightedPaths(&sb, paths)
+
+	return sb.String()
+}
@@ -0,0 +1,281 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find Minimum Spanning Tree using Kruskal's and Borůvka's algorithms
+ *
+ * Kruskal's Algorithm - take edges from the cheapest one, skip those closing a cycle
+ * Borůvka's Algorithm - every component picks its cheapest outgoing edge, repeat
+ *
+ * Both are built on DisjointSet and naturally produce a spanning forest, so
+ * with WithMSTForest(true) disconnected graphs get a tree per component.
+ * Unlike Prim, they refuse directed graphs: spanning tree of a directed graph
+ * is an arborescence, which is another task.
+ */
+
+// MSTComparison represents the cross-check of all MST algorithms
+type MSTComparison struct {
+	Results []*MSTResult // Result of every algorithm
+	Agree   bool         // Whether all algorithms agree on possibility and total weight
+	Message string       // Status message
+}
+
+// FindMSTKruskal finds Minimum Spanning Tree using Kruskal's algorithm
+// Time Complexity: O(E log E)
+func FindMSTKruskal(gr *graph.Graph, options ...graph.Option[MSTOptions]) (*MSTResult, error) {
+	opts, err := prepareMST(gr, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 1: Sort edges from best to worst
+	edges := make([]*graph.Edge, 0, len(gr.Edges))
+	for _, edge := range gr.Edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool { return isBetterMSTEdge(edges[i], edges[j], opts.Maximum) })
+
+	// Step 2: Take every edge that joins two different trees
+	ds := MakeDisjointSet(getSortedKeys(gr.Nodes)...)
+	mstEdges := []*graph.Edge{}
+	for _, edge := range edges {
+		if ds.Union(edge.Source, edge.Destination) {
+			mstEdges = append(mstEdges, edge)
+		}
+	}
+
+	return buildMSTResult("Kruskal", mstEdges, ds.Count(), opts), nil
+}
+
+// FindMSTBoruvka finds Minimum Spanning Tree using Borůvka's algorithm
+// Time Complexity: O(E log V), number of components at least halves every round
+func FindMSTBoruvka(gr *graph.Graph, options ...graph.Option[MSTOptions]) (*MSTResult, error) {
+	opts, err := prepareMST(gr, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := MakeDisjointSet(getSortedKeys(gr.Nodes)...)
+	mstEdges := []*graph.Edge{}
+
+	for {
+		// Step 1: Every component finds its best outgoing edge. Ties are broken by
+		// edge key, otherwise equal weights could make components close a cycle
+		best := make(map[graph.TKey]*graph.Edge)
+		for _, edge := range gr.Edges {
+			rootU, rootV := ds.Find(edge.Source), ds.Find(edge.Destination)
+			if rootU == rootV {
+				continue
+			}
+			for _, root := range []graph.TKey{rootU, rootV} {
+				if best[root] == nil || isBetterMSTEdge(edge, best[root], opts.Maximum) {
+					best[root] = edge
+				}
+			}
+		}
+
+		// No component can grow anymore: this is a spanning forest
+		if len(best) == 0 {
+			break
+		}
+
+		// Step 2: Add all chosen edges. Two components may choose the same edge
+		for _, root := range getSortedMapKeys(best) {
+			edge := best[root]
+			if ds.Union(edge.Source, edge.Destination) {
+				mstEdges = append(mstEdges, edge)
+			}
+		}
+	}
+
+	return buildMSTResult("Borůvka", mstEdges, ds.Count(), opts), nil
+}
+
+// FindMinimumSpanningForest finds a minimum spanning tree of every connected component
+func FindMinimumSpanningForest(gr *graph.Graph) (*MSTResult, error) {
+	return FindMSTKruskal(gr, WithMSTForest(true))
+}
+
+// CompareMSTAlgorithms runs Prim, Kruskal and Borůvka and checks they agree on total weight
+// Spanning trees may differ when weights repeat, but their total weight may not
+func CompareMSTAlgorithms(gr *graph.Graph, options ...graph.Option[MSTOptions]) (*MSTComparison, error) {
+	algorithms := []func(*graph.Graph, ...graph.Option[MSTOptions]) (*MSTResult, error){
+		FindMSTPrim, FindMSTKruskal, FindMSTBoruvka,
+	}
+
+	results := []*MSTResult{}
+	for _, algorithm := range algorithms {
+		result, err := algorithm(gr, options...)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	agree := true
+	for _, result := range results[1:] {
+		if result.IsPossible != results[0].IsPossible || result.TotalWeight != results[0].TotalWeight {
+			agree = false
+		}
+	}
+
+	message := fmt.Sprintf("All %d algorithms agree on total weight %d", len(results), results[0].TotalWeight)
+	if !agree {
+		message = "MST algorithms DISAGREE on total weight"
+	}
+
+	return &MSTComparison{
+		Results: results,
+		Agree:   agree,
+		Message: message,
+	}, nil
+}
+
+// prepareMST validates the graph and collects options
+func prepareMST(gr *graph.Graph, options []graph.Option[MSTOptions]) (MSTOptions, error) {
+	opts := MSTOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if gr.Nodes == nil {
+		return opts, graph.ThrowNodesListIsNil()
+	}
+	if gr.Options.IsDirected {
+		return opts, graph.ThrowGraphDirected()
+	}
+
+	return opts, nil
+}
+
+// buildMSTResult packs found edges. Without forest mode several trees mean failure
+func buildMSTResult(algorithm string, mstEdges []*graph.Edge, components int, opts MSTOptions) *MSTResult {
+	if components > 1 && !opts.Forest {
+		return &MSTResult{
+			TotalWeight: 0,
+			Edges:       []*graph.Edge{},
+			IsPossible:  false,
+			Components:  components,
+			Algorithm:   algorithm,
+			IsMaximum:   opts.Maximum,
+		}
+	}
+
+	totalWeight := graph.TWeight(0)
+	for _, edge := range mstEdges {
+		totalWeight += edge.Weight
+	}
+
+	return &MSTResult{
+		TotalWeight: totalWeight,
+		Edges:       mstEdges,
+		IsPossible:  true,
+		Components:  components,
+		Algorithm:   algorithm,
+		IsMaximum:   opts.Maximum,
+	}
+}
+
+// isBetterMSTEdge compares edges by weight, then by key to make ties deterministic
+func isBetterMSTEdge(a, b *graph.Edge, maximum bool) bool {
+	if a.Weight != b.Weight {
+		if maximum {
+			return a.Weight > b.Weight
+		}
+		return a.Weight < b.Weight
+	}
+	return a.Key < b.Key
+}
+
+// getSortedMapKeys returns sorted keys of any map keyed by node keys
+func getSortedMapKeys[V any](m map[graph.TKey]V) []graph.TKey {
+	keys := make([]graph.TKey, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// FormatMSTResult creates a formatted string representation
+func (result *MSTResult) FormatMSTResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	kind := "MINIMUM"
+	if result.IsMaximum {
+		kind = "MAXIMUM"
+	}
+	shape := "TREE"
+	if result.Components > 1 {
+		shape = "FOREST"
+	}
+
+	if !result.IsPossible {
+		sb.WriteString(fmt.Sprintf("%s SPANNING TREE ANALYSIS\n\n", kind))
+		sb.WriteString("MST is NOT possible for this graph\n\n")
+		sb.WriteString(fmt.Sprintf("Reason: Graph is not connected (%d components)\n", result.Components))
+		sb.WriteString("Use spanning forest mode to get a tree for every component.")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("%s SPANNING %s (%s's Algorithm)\n\n", kind, shape, result.Algorithm))
+	if result.Algorithm == "Prim" && gr.Options.IsDirected {
+		sb.WriteString("Note: directed graph is treated as undirected. Use arborescence for directed graphs\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("Total weight: %d\n", result.TotalWeight))
+	sb.WriteString(fmt.Sprintf("Number of edges: %d\n", len(result.Edges)))
+	sb.WriteString(fmt.Sprintf("Number of trees: %d\n", result.Components))
+	sb.WriteString(fmt.Sprintf("Theoretical edges: %d\n\n", len(gr.Nodes)-result.Components))
+
+	sb.WriteString("EDGES:\n")
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s %s\n", "Key", "From", "To", "Weight", "Label"))
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s %s\n", "────", "────", "──", "──────", "─────"))
+
+	for _, edge := range result.Edges {
+		srcLabel := fmt.Sprintf("%d", edge.Source)
+		if node, _ := gr.GetNodeByKey(edge.Source); node != nil && node.Label != "" {
+			srcLabel = fmt.Sprintf("%d(%s)", edge.Source, node.Label)
+		}
+
+		dstLabel := fmt.Sprintf("%d", edge.Destination)
+		if node, _ := gr.GetNodeByKey(edge.Destination); node != nil && node.Label != "" {
+			dstLabel = fmt.Sprintf("%d(%s)", edge.Destination, node.Label)
+		}
+
+		sb.WriteString(fmt.Sprintf("%-8d %-8s %-8s %-12d %s\n",
+			edge.Key, srcLabel, dstLabel, edge.Weight, edge.Label))
+	}
+
+	sb.WriteString("\nGRAPH INFORMATION:\n")
+	sb.WriteString(fmt.Sprintf("Original graph: %d nodes, %d edges\n", len(gr.Nodes), len(gr.Edges)))
+	sb.WriteString(fmt.Sprintf("Result covers: %d nodes, %d edges\n", len(gr.Nodes), len(result.Edges)))
+
+	return sb.String()
+}
+
+// FormatMSTComparison creates a formatted string representation
+func (comparison *MSTComparison) FormatMSTComparison() string {
+	var sb strings.Builder
+
+	sb.WriteString("MST ALGORITHMS CROSS-CHECK\n\n")
+	sb.WriteString(fmt.Sprintf("%-12s %-10s %-10s %-8s\n", "Algorithm", "Possible", "Weight", "Edges"))
+	sb.WriteString(strings.Repeat("─", 44) + "\n")
+	for _, result := range comparison.Results {
+		sb.WriteString(fmt.Sprintf("%-12s %-10v %-10d %-8d\n",
+			result.Algorithm, result.IsPossible, result.TotalWeight, len(result.Edges)))
+	}
+	sb.WriteString("\n" + comparison.Message + "\n")
+
+	return sb.String()
+}
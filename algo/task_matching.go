@@ -0,0 +1,439 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find weighted assignment in bipartite graph and maximum matching in general graph
+ *
+ * Hungarian Algorithm (Kuhn-Munkres) - cheapest perfect matching of square
+ * cost matrix. Potentials u(i) + v(j) <= cost(i, j) are kept for rows and
+ * columns; rows are added one by one, growing a tree of tight edges (where
+ * the inequality is an equality) and raising potentials by the smallest slack
+ * until a free column is reached. Time Complexity: O(V^3)
+ *
+ * Missing edges get a huge cost, larger than any difference of real weights,
+ * so the best assignment first uses as many real edges as possible, then
+ * minimizes their weight. When the graph has no perfect matching, the result
+ * is the cheapest among maximum matchings. Maximum weight is the minimum of
+ * negated weights.
+ *
+ * Edmonds' Blossom Algorithm - augmenting paths in general graph. Alternating
+ * BFS from a free vertex; when an edge joins two even vertices, it closes an
+ * odd cycle (blossom), which is contracted into its base and searched as one
+ * vertex. Augmenting path in contracted graph lifts to the original one.
+ * Time Complexity: O(V^3)
+ */
+
+// AssignmentOptions configures weighted assignment
+type AssignmentOptions struct {
+	Maximize bool // Find the heaviest assignment instead of the cheapest
+}
+
+func WithAssignmentMaximize(maximize bool) graph.Option[AssignmentOptions] {
+	return func(opts *AssignmentOptions) {
+		opts.Maximize = maximize
+	}
+}
+
+// AssignmentResult represents weighted matching of bipartite graph
+type AssignmentResult struct {
+	TotalWeight graph.TWeight             // Sum of weights of matched edges
+	Size        int                       // Number of matched edges
+	Edges       []graph.TKey              // Keys of matched edges, sorted
+	Mate        map[graph.TKey]graph.TKey // Partner of every matched vertex
+	Left        []graph.TKey              // Left side of bipartition
+	Right       []graph.TKey              // Right side of bipartition
+	Maximize    bool                      // True if weight was maximized
+	IsPerfect   bool                      // Every vertex is matched
+	Message     string                    // Status message
+}
+
+// FindAssignment finds minimum (or maximum) weight perfect matching of bipartite graph
+// using Hungarian algorithm. Without perfect matching, the best maximum matching is returned
+func FindAssignment(gr *graph.Graph, options ...graph.Option[AssignmentOptions]) (*AssignmentResult, error) {
+	opts := AssignmentOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	bipartite, err := IsBipartite(gr)
+	if err != nil {
+		return nil, err
+	}
+	if !bipartite.IsBipartite {
+		return nil, fmt.Errorf("graph is not bipartite, odd cycle: %s", formatTrail(bipartite.OddCycle))
+	}
+
+	left, right := bipartite.Left, bipartite.Right
+	leftIndex := make(map[graph.TKey]int, len(left))
+	for i, key := range left {
+		leftIndex[key] = i
+	}
+	rightIndex := make(map[graph.TKey]int, len(right))
+	for i, key := range right {
+		rightIndex[key] = i
+	}
+
+	// Step 1: Best edge for every pair, parallel edges compete
+	sign := graph.TWeight(1)
+	if opts.Maximize {
+		sign = -1
+	}
+	best := make(map[[2]int]graph.TKey)
+	total := int64(0)
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		u, v := edge.Source, edge.Destination
+		if _, ok := leftIndex[u]; !ok {
+			u, v = v, u
+		}
+		pair := [2]int{leftIndex[u], rightIndex[v]}
+		if current, ok := best[pair]; !ok || sign*edge.Weight < sign*gr.Edges[current].Weight {
+			best[pair] = key
+		}
+		total += int64(max(edge.Weight, -edge.Weight))
+	}
+
+	// Step 2: Square cost matrix, missing pairs are worse than any set of real ones
+	size := max(len(left), len(right))
+	missing := 2*total + 1
+	cost := make([][]int64, size)
+	for i := range cost {
+		cost[i] = make([]int64, size)
+		for j := range cost[i] {
+			cost[i][j] = missing
+			if key, ok := best[[2]int{i, j}]; ok {
+				cost[i][j] = int64(sign * gr.Edges[key].Weight)
+			}
+		}
+	}
+
+	// Step 3: Hungarian algorithm
+	assigned := hungarian(cost)
+
+	result := &AssignmentResult{
+		Edges:    []graph.TKey{},
+		Mate:     make(map[graph.TKey]graph.TKey),
+		Left:     left,
+		Right:    right,
+		Maximize: opts.Maximize,
+	}
+	for i, j := range assigned {
+		key, ok := best[[2]int{i, j}]
+		if !ok {
+			continue // Dummy row or column, or missing edge
+		}
+		result.Edges = append(result.Edges, key)
+		result.Mate[left[i]] = right[j]
+		result.Mate[right[j]] = left[i]
+		result.TotalWeight += gr.Edges[key].Weight
+	}
+	slices.Sort(result.Edges)
+
+	result.Size = len(result.Edges)
+	result.IsPerfect = 2*result.Size == len(gr.Nodes)
+
+	objective := "minimum"
+	if opts.Maximize {
+		objective = "maximum"
+	}
+	if result.IsPerfect {
+		result.Message = fmt.Sprintf("Found %s weight perfect assignment: %d edge(s), total weight %d",
+			objective, result.Size, result.TotalWeight)
+	} else {
+		result.Message = fmt.Sprintf("No perfect assignment, %s weight maximum matching: %d edge(s), total weight %d",
+			objective, result.Size, result.TotalWeight)
+	}
+
+	return result, nil
+}
+
+// hungarian returns column assigned to every row of square matrix, minimizing total cost
+func hungarian(cost [][]int64) []int {
+	n := len(cost)
+
+	// Rows and columns are numbered from 1, column 0 is a fake start of every search
+	u := make([]int64, n+1)
+	v := make([]int64, n+1)
+	rowOf := make([]int, n+1) // Row assigned to column, 0 if none
+	way := make([]int, n+1)   // Previous column on the alternating path
+
+	for i := 1; i <= n; i++ {
+		rowOf[0] = i
+		column := 0
+		minSlack := make([]int64, n+1)
+		used := make([]bool, n+1)
+		for j := range minSlack {
+			minSlack[j] = math.MaxInt64
+		}
+
+		for rowOf[column] != 0 {
+			used[column] = true
+			row := rowOf[column]
+			delta := int64(math.MaxInt64)
+			next := 0
+
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if slack := cost[row-1][j-1] - u[row] - v[j]; slack < minSlack[j] {
+					minSlack[j] = slack
+					way[j] = column
+				}
+				if minSlack[j] < delta {
+					delta = minSlack[j]
+					next = j
+				}
+			}
+
+			for j := 0; j <= n; j++ {
+				if used[j] {
+					u[rowOf[j]] += delta
+					v[j] -= delta
+				} else {
+					minSlack[j] -= delta
+				}
+			}
+			column = next
+		}
+
+		// Flip the alternating path back to the start
+		for column != 0 {
+			previous := way[column]
+			rowOf[column] = rowOf[previous]
+			column = previous
+		}
+	}
+
+	assigned := make([]int, n)
+	for j := 1; j <= n; j++ {
+		assigned[rowOf[j]-1] = j - 1
+	}
+	return assigned
+}
+
+// FindMaximumMatching finds maximum matching of undirected graph using Edmonds' blossom algorithm
+func FindMaximumMatching(gr *graph.Graph) (*MatchingResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if gr.Options.IsDirected {
+		return nil, graph.ThrowGraphDirected()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	// Simple adjacency: loops never match, parallel edges are the same pair
+	n := len(keys)
+	adj := make([][]int, n)
+	pairEdge := make(map[[2]int]graph.TKey)
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		a, b := index[edge.Source], index[edge.Destination]
+		if a == b {
+			continue
+		}
+		if _, ok := pairEdge[[2]int{a, b}]; ok {
+			continue
+		}
+		pairEdge[[2]int{a, b}] = key
+		pairEdge[[2]int{b, a}] = key
+		adj[a] = append(adj[a], b)
+		adj[b] = append(adj[b], a)
+	}
+
+	state := &blossomState{
+		adj:     adj,
+		match:   make([]int, n),
+		parent:  make([]int, n),
+		base:    make([]int, n),
+		used:    make([]bool, n),
+		blossom: make([]bool, n),
+	}
+	for i := range state.match {
+		state.match[i] = -1
+	}
+
+	size := 0
+	for root := range n {
+		if state.match[root] != -1 {
+			continue
+		}
+		end := state.findAugmentingPath(root)
+		if end == -1 {
+			continue
+		}
+
+		// Flip matched and unmatched edges along the path
+		for v := end; v != -1; {
+			u := state.parent[v]
+			next := state.match[u]
+			state.match[v] = u
+			state.match[u] = v
+			v = next
+		}
+		size++
+	}
+
+	result := &MatchingResult{
+		Size:      size,
+		Edges:     []graph.TKey{},
+		Mate:      make(map[graph.TKey]graph.TKey, 2*size),
+		Algorithm: "Edmonds' blossom",
+	}
+	for v, u := range state.match {
+		if u == -1 {
+			continue
+		}
+		result.Mate[keys[v]] = keys[u]
+		if v < u {
+			result.Edges = append(result.Edges, pairEdge[[2]int{v, u}])
+		}
+	}
+	slices.Sort(result.Edges)
+	result.Message = fmt.Sprintf("Maximum matching has %d edge(s), %d vertices left unmatched", size, n-2*size)
+
+	return result, nil
+}
+
+// blossomState keeps alternating forest of Edmonds' algorithm, vertices are indices
+type blossomState struct {
+	adj     [][]int
+	match   []int  // Matched partner, -1 if free
+	parent  []int  // Odd vertex → even vertex it was reached from
+	base    []int  // Base of blossom containing vertex
+	used    []bool // Vertex is even (in the queue)
+	blossom []bool // Base belongs to blossom being contracted
+	queue   []int
+}
+
+// findAugmentingPath searches from free root, returns free end of augmenting path or -1
+func (state *blossomState) findAugmentingPath(root int) int {
+	for i := range state.base {
+		state.used[i] = false
+		state.parent[i] = -1
+		state.base[i] = i
+	}
+	state.used[root] = true
+	state.queue = append(state.queue[:0], root)
+
+	for head := 0; head < len(state.queue); head++ {
+		v := state.queue[head]
+		for _, to := range state.adj[v] {
+			if state.base[v] == state.base[to] || state.match[v] == to {
+				continue
+			}
+
+			if to == root || (state.match[to] != -1 && state.parent[state.match[to]] != -1) {
+				// Both ends are even: contract the blossom
+				base := state.commonBase(v, to)
+				clear(state.blossom)
+				state.markPath(v, base, to)
+				state.markPath(to, base, v)
+				for i := range state.base {
+					if state.blossom[state.base[i]] {
+						state.base[i] = base
+						if !state.used[i] {
+							state.used[i] = true
+							state.queue = append(state.queue, i)
+						}
+					}
+				}
+			} else if state.parent[to] == -1 {
+				state.parent[to] = v
+				if state.match[to] == -1 {
+					return to
+				}
+				state.used[state.match[to]] = true
+				state.queue = append(state.queue, state.match[to])
+			}
+		}
+	}
+
+	return -1
+}
+
+// commonBase finds base of the blossom closed by edge between even vertices a and b
+func (state *blossomState) commonBase(a, b int) int {
+	seen := make([]bool, len(state.base))
+	for {
+		a = state.base[a]
+		seen[a] = true
+		if state.match[a] == -1 {
+			break // Reached the root
+		}
+		a = state.parent[state.match[a]]
+	}
+	for {
+		b = state.base[b]
+		if seen[b] {
+			return b
+		}
+		b = state.parent[state.match[b]]
+	}
+}
+
+// markPath marks blossoms on the way from v to base, setting parents to walk around the cycle
+func (state *blossomState) markPath(v, base, child int) {
+	for state.base[v] != base {
+		state.blossom[state.base[v]] = true
+		state.blossom[state.base[state.match[v]]] = true
+		state.parent[v] = child
+		child = state.match[v]
+		v = state.parent[state.match[v]]
+	}
+}
+
+// FormatAssignmentResult creates a formatted string representation
+func (result *AssignmentResult) FormatAssignmentResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	objective := "MINIMUM"
+	if result.Maximize {
+		objective = "MAXIMUM"
+	}
+
+	sb.WriteString(fmt.Sprintf("%s WEIGHT ASSIGNMENT (Hungarian)\n\n", objective))
+	sb.WriteString(fmt.Sprintf("Left side (%d): %s\n", len(result.Left), formatKeyList(result.Left)))
+	sb.WriteString(fmt.Sprintf("Right side (%d): %s\n", len(result.Right), formatKeyList(result.Right)))
+	sb.WriteString(fmt.Sprintf("Perfect: %v\n", result.IsPerfect))
+	sb.WriteString(fmt.Sprintf("Matched edges: %d\n", result.Size))
+	sb.WriteString(fmt.Sprintf("Total weight: %d\n\n", result.TotalWeight))
+
+	sb.WriteString("ASSIGNMENT:\n")
+	sb.WriteString(fmt.Sprintf("%-20s %-20s %-8s %-10s\n", "Left", "Right", "Edge", "Weight"))
+	sb.WriteString(strings.Repeat("─", 60) + "\n")
+	for _, key := range result.Left {
+		mate, ok := result.Mate[key]
+		if !ok {
+			sb.WriteString(fmt.Sprintf("%-20s %-20s\n", formatNodeName(gr, key), "-"))
+			continue
+		}
+		for _, edgeKey := range result.Edges {
+			edge := gr.Edges[edgeKey]
+			if (edge.Source == key && edge.Destination == mate) || (edge.Source == mate && edge.Destination == key) {
+				sb.WriteString(fmt.Sprintf("%-20s %-20s %-8d %-10d\n",
+					formatNodeName(gr, key), formatNodeName(gr, mate), edgeKey, edge.Weight))
+				break
+			}
+		}
+	}
+
+	return sb.String()
+}
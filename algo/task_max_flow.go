@@ -6,6 +6,7 @@ package algo
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
@@ -13,277 +14,712 @@ import (
 )
 
 /*
- * Task: Find maximum flow using Edmonds-Karp algorithm (BFS-based Ford-Fulkerson)
+ * Task: Find maximum flow using Edmonds-Karp, Dinic or push-relabel algorithm
+ *
+ * Edmonds-Karp - augment along shortest paths found by BFS, O(V * E^2)
+ * Dinic - augment blocking flows in BFS level graph, O(V^2 * E)
+ * Push-Relabel - highest-label variant with gap heuristic, O(V^2 * sqrt(E))
+ *
+ * All algorithms run on the same flowNetwork: every edge of the graph gives its
+ * own pair of arcs (forward arc and its residual twin). So parallel edges of a
+ * multigraph are separate capacities adding up, and flow through undirected
+ * edge may go either way. Edge weight is its capacity, zero or negative weights
+ * count as capacity 1.
+ *
+ * Several sources or sinks are joined by a virtual super-source or super-sink.
+ * Node with limited capacity is split in two: its "in" half receives all
+ * incoming arcs, its "out" half sends all outgoing ones, and a single arc
+ * in → out carries the node capacity.
  */
 
+// MaxFlowAlgorithm names an algorithm used to find maximum flow
+type MaxFlowAlgorithm string
+
+const (
+	MaxFlowDinic       MaxFlowAlgorithm = "Dinic"
+	MaxFlowPushRelabel MaxFlowAlgorithm = "Push-Relabel"
+	MaxFlowEdmondsKarp MaxFlowAlgorithm = "Edmonds-Karp"
+)
+
+// MaxFlowAlgorithms lists all available algorithms, the default one goes first
+var MaxFlowAlgorithms = []MaxFlowAlgorithm{MaxFlowDinic, MaxFlowPushRelabel, MaxFlowEdmondsKarp}
+
 // FlowEdge represents an edge with flow information
 type FlowEdge struct {
+	EdgeKey     graph.TKey    `json:"edge_key"`
 	Source      graph.TKey    `json:"source"`
 	Destination graph.TKey    `json:"destination"`
 	Capacity    graph.TWeight `json:"capacity"`
 	Flow        graph.TWeight `json:"flow"`
+	Cost        graph.TWeight `json:"cost,omitempty"`
 }
 
 // MaxFlowResult contains the result of maximum flow calculation
 type MaxFlowResult struct {
-	MaxFlowValue graph.TWeight `json:"max_flow_value"`
-	Source       graph.TKey    `json:"source"`
-	Sink         graph.TKey    `json:"sink"`
-	FlowEdges    []FlowEdge    `json:"flow_edges"`
-	MinCut       []graph.TKey  `json:"min_cut"`
-	Message      string        `json:"message"`
+	MaxFlowValue  graph.TWeight      `json:"max_flow_value"`
+	Source        graph.TKey         `json:"source"`
+	Sink          graph.TKey         `json:"sink"`
+	Sources       []graph.TKey       `json:"sources"`
+	Sinks         []graph.TKey       `json:"sinks"`
+	FlowEdges     []FlowEdge         `json:"flow_edges"`
+	MinCut        []graph.TKey       `json:"min_cut"`       // Nodes on the source side of minimum cut
+	CutEdges      []FlowEdge         `json:"cut_edges"`     // Saturated edges leaving the source side
+	CutNodes      []graph.TKey       `json:"cut_nodes"`     // Saturated capacitated nodes on the border of the cut
+	Decomposition *FlowDecomposition `json:"decomposition"` // Flow split into paths and cycles
+	Algorithm     string             `json:"algorithm"`
+	Message       string             `json:"message"`
+}
+
+// MaxFlowOptions configures maximum flow calculation
+type MaxFlowOptions struct {
+	Algorithm      MaxFlowAlgorithm             // Algorithm to use, Dinic by default
+	Sources        []graph.TKey                 // Extra sources besides the source argument
+	Sinks          []graph.TKey                 // Extra sinks besides the sink argument
+	NodeCapacities map[graph.TKey]graph.TWeight // Flow allowed through node, unlisted nodes are unlimited
+	UnitCapacities bool                         // Every edge has capacity 1, whatever its weight
+}
+
+func WithMaxFlowAlgorithm(algorithm MaxFlowAlgorithm) graph.Option[MaxFlowOptions] {
+	return func(opts *MaxFlowOptions) {
+		opts.Algorithm = algorithm
+	}
+}
+
+func WithMaxFlowSources(sources ...graph.TKey) graph.Option[MaxFlowOptions] {
+	return func(opts *MaxFlowOptions) {
+		opts.Sources = append(opts.Sources, sources...)
+	}
+}
+
+func WithMaxFlowSinks(sinks ...graph.TKey) graph.Option[MaxFlowOptions] {
+	return func(opts *MaxFlowOptions) {
+		opts.Sinks = append(opts.Sinks, sinks...)
+	}
+}
+
+func WithUnitCapacities(unit bool) graph.Option[MaxFlowOptions] {
+	return func(opts *MaxFlowOptions) {
+		opts.UnitCapacities = unit
+	}
+}
+
+func WithNodeCapacities(capacities map[graph.TKey]graph.TWeight) graph.Option[MaxFlowOptions] {
+	return func(opts *MaxFlowOptions) {
+		opts.NodeCapacities = capacities
+	}
+}
+
+// maxFlowSolver is the common interface of all max-flow algorithms. Solver
+// pushes as much flow as possible from s to t, changing residual capacities
+// of the network, and returns the amount of flow pushed
+type maxFlowSolver interface {
+	maxFlow(net *flowNetwork, s, t int) int64
 }
 
-// FindMaxFlow finds maximum flow from source to sink using Edmonds-Karp algorithm
-func FindMaxFlow(gr *graph.Graph, source, sink graph.TKey) (*MaxFlowResult, error) {
+type edmondsKarpSolver struct{}
+type dinicSolver struct{}
+type pushRelabelSolver struct{}
+
+// FindMaxFlow finds maximum flow from source to sink. Extra sources, sinks and
+// node capacities are given by options
+func FindMaxFlow(gr *graph.Graph, source, sink graph.TKey, options ...graph.Option[MaxFlowOptions]) (*MaxFlowResult, error) {
 	if gr.Nodes == nil {
 		return nil, graph.ThrowNodesListIsNil()
 	}
 
-	// Validate source and sink nodes
+	opts := MaxFlowOptions{Algorithm: MaxFlowDinic}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	solver, err := getMaxFlowSolver(opts.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := uniqueKeys(append([]graph.TKey{source}, opts.Sources...))
+	sinks := uniqueKeys(append([]graph.TKey{sink}, opts.Sinks...))
+	if err := validateFlowTerminalSets(gr, sources, sinks); err != nil {
+		return nil, err
+	}
+	for _, key := range getSortedMapKeys(opts.NodeCapacities) {
+		if _, err := gr.GetNodeByKey(key); err != nil {
+			return nil, fmt.Errorf("capacity given for missing node %d", key)
+		}
+		if opts.NodeCapacities[key] < 0 {
+			return nil, fmt.Errorf("node %d has negative capacity %d", key, opts.NodeCapacities[key])
+		}
+	}
+
+	net := newFlowNetwork(gr, opts)
+	s, t := net.joinTerminals(sources, sinks)
+	maxFlow := graph.TWeight(solver.maxFlow(net, s, t))
+	cutEdges, cutNodes := net.findCut(gr, s)
+	flowEdges := buildFlowEdges(gr, net)
+
+	message := fmt.Sprintf("Maximum flow from %d to %d is %d", source, sink, maxFlow)
+	if len(sources) > 1 || len(sinks) > 1 {
+		message = fmt.Sprintf("Maximum flow from {%s} to {%s} is %d", formatKeyList(sources), formatKeyList(sinks), maxFlow)
+	}
+
+	return &MaxFlowResult{
+		MaxFlowValue:  maxFlow,
+		Source:        source,
+		Sink:          sink,
+		Sources:       sources,
+		Sinks:         sinks,
+		FlowEdges:     flowEdges,
+		MinCut:        net.sourceSide(s),
+		CutEdges:      cutEdges,
+		CutNodes:      cutNodes,
+		Decomposition: DecomposeFlow(flowEdges, sources, sinks),
+		Algorithm:     string(opts.Algorithm),
+		Message:       message,
+	}, nil
+}
+
+// validateFlowTerminalSets checks that all sources and sinks exist and no node is both
+func validateFlowTerminalSets(gr *graph.Graph, sources, sinks []graph.TKey) error {
+	for _, source := range sources {
+		for _, sink := range sinks {
+			if err := validateFlowTerminals(gr, source, sink); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// uniqueKeys drops repeated keys, keeping order of first occurrences
+func uniqueKeys(keys []graph.TKey) []graph.TKey {
+	seen := make(map[graph.TKey]bool, len(keys))
+	unique := []graph.TKey{}
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
+// validateFlowTerminals checks that source and sink exist and differ
+func validateFlowTerminals(gr *graph.Graph, source, sink graph.TKey) error {
 	if _, err := gr.GetNodeByKey(source); err != nil {
-		return nil, fmt.Errorf("source node %d does not exist", source)
+		return fmt.Errorf("source node %d does not exist", source)
 	}
 
 	if _, err := gr.GetNodeByKey(sink); err != nil {
-		return nil, fmt.Errorf("sink node %d does not exist", sink)
+		return fmt.Errorf("sink node %d does not exist", sink)
 	}
 
 	if source == sink {
-		return nil, fmt.Errorf("source and sink cannot be the same node")
+		return fmt.Errorf("source and sink cannot be the same node")
 	}
 
-	// Create residual graph and initialize flow
-	residualGraph := createResidualGraph(gr)
-	flowMap := initializeFlowMap(gr)
+	return nil
+}
 
-	maxFlow := graph.TWeight(0)
+// getMaxFlowSolver returns solver implementing the algorithm
+func getMaxFlowSolver(algorithm MaxFlowAlgorithm) (maxFlowSolver, error) {
+	switch algorithm {
+	case MaxFlowEdmondsKarp:
+		return edmondsKarpSolver{}, nil
+	case MaxFlowDinic:
+		return dinicSolver{}, nil
+	case MaxFlowPushRelabel:
+		return pushRelabelSolver{}, nil
+	}
+	return nil, fmt.Errorf("unknown max flow algorithm %q", algorithm)
+}
 
-	// Edmonds-Karp algorithm: repeatedly find augmenting paths using BFS
-	for {
-		// Find augmenting path in residual graph
-		path, parent := findAugmentingPath(residualGraph, source, sink)
-		if path == nil {
-			break // No more augmenting paths
-		}
+// flowNetwork is a residual network stored in flat arrays. Arcs go in pairs:
+// arc^1 is the residual twin of arc, so pushing flow along one frees the other
+type flowNetwork struct {
+	keys       []graph.TKey       // Node key of every graph vertex, auxiliary vertices have none
+	index      map[graph.TKey]int // Vertex index of every node key
+	adj        [][]int            // Arcs leaving every vertex
+	to         []int              // Head of every arc
+	residual   []int64            // Residual capacity of every arc
+	capacity   []int64            // Initial capacity of every arc
+	cost       []int64            // Cost of unit of flow along every arc, twin has the opposite one
+	edgeArc    map[graph.TKey]int // Forward arc of every graph edge
+	reverseArc map[graph.TKey]int // Arc of the opposite direction, for undirected edges with cost or split nodes
+	out        []int              // Vertex sending arcs of every graph vertex, "out" half of split node
+	nodeArc    map[graph.TKey]int // Arc in → out of every split node
+}
 
-		// Find bottleneck capacity in the path
-		pathFlow := findBottleneckCapacity(residualGraph, path, parent, sink)
+// newFlowNetwork builds network with an arc pair for every edge of the graph
+// and splits nodes with limited capacity
+func newFlowNetwork(gr *graph.Graph, opts MaxFlowOptions) *flowNetwork {
+	nodeCapacities := opts.NodeCapacities
+	net := &flowNetwork{
+		index:      make(map[graph.TKey]int, len(gr.Nodes)),
+		edgeArc:    make(map[graph.TKey]int, len(gr.Edges)),
+		reverseArc: make(map[graph.TKey]int),
+		nodeArc:    make(map[graph.TKey]int, len(nodeCapacities)),
+	}
 
-		// Update residual capacities and flow along the path
-		updateResidualGraph(residualGraph, flowMap, path, parent, pathFlow, sink, gr)
+	for _, key := range getSortedKeys(gr.Nodes) {
+		net.index[key] = net.addVertex()
+		net.keys = append(net.keys, key)
+		net.out = append(net.out, net.index[key])
+	}
 
-		maxFlow += pathFlow
+	for _, key := range getSortedMapKeys(nodeCapacities) {
+		in := net.index[key]
+		net.out[in] = net.addVertex()
+		net.nodeArc[key] = net.addArc(in, net.out[in], int64(nodeCapacities[key]), 0)
 	}
 
-	// Build result
-	flowEdges := buildFlowEdges(gr, flowMap)
-	minCut := findMinCut(residualGraph, source)
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		if edge.Source == edge.Destination {
+			continue // Loop never carries flow
+		}
 
-	return &MaxFlowResult{
-		MaxFlowValue: maxFlow,
-		Source:       source,
-		Sink:         sink,
-		FlowEdges:    flowEdges,
-		MinCut:       minCut,
-		Message:      fmt.Sprintf("Maximum flow from %d to %d is %d", source, sink, maxFlow),
-	}, nil
+		u, v := net.index[edge.Source], net.index[edge.Destination]
+		capacity := edgeCapacity(edge)
+		if opts.UnitCapacities {
+			capacity = 1
+		}
+
+		switch {
+		case gr.Options.IsDirected:
+			net.edgeArc[key] = net.addArc(net.out[u], v, capacity, 0)
+		case len(nodeCapacities) == 0:
+			net.edgeArc[key] = net.addArc(u, v, capacity, capacity)
+		default:
+			// Twin arc would go between wrong halves of split nodes
+			net.edgeArc[key] = net.addArc(net.out[u], v, capacity, 0)
+			net.reverseArc[key] = net.addArc(net.out[v], u, capacity, 0)
+		}
+	}
+
+	return net
 }
 
-// createResidualGraph creates the residual graph from original graph
-func createResidualGraph(gr *graph.Graph) map[graph.TKey]map[graph.TKey]graph.TWeight {
-	residual := make(map[graph.TKey]map[graph.TKey]graph.TWeight)
+// joinTerminals returns single source and sink vertices of the network, adding
+// super-source and super-sink when there are several of them
+func (net *flowNetwork) joinTerminals(sources, sinks []graph.TKey) (int, int) {
+	// Virtual arcs must never limit the flow, so they get more than all arcs together
+	unbounded := int64(1)
+	for _, capacity := range net.capacity {
+		unbounded += capacity
+	}
 
-	// Initialize residual capacities
-	for u := range gr.Nodes {
-		residual[u] = make(map[graph.TKey]graph.TWeight)
-		for v := range gr.Nodes {
-			residual[u][v] = 0
+	s := net.index[sources[0]]
+	if len(sources) > 1 {
+		s = net.addVertex()
+		for _, source := range sources {
+			net.addArc(s, net.index[source], unbounded, 0)
 		}
 	}
 
-	// Set initial capacities from original edges
-	for _, edge := range gr.Edges {
-		capacity := edge.Weight
-		if capacity <= 0 {
-			capacity = 1 // Default capacity for zero/negative weights
+	t := net.out[net.index[sinks[0]]]
+	if len(sinks) > 1 {
+		t = net.addVertex()
+		for _, sink := range sinks {
+			net.addArc(net.out[net.index[sink]], t, unbounded, 0)
 		}
-		residual[edge.Source][edge.Destination] = capacity
 	}
 
-	return residual
+	return s, t
 }
 
-// initializeFlowMap creates initial flow map with zero flow
-func initializeFlowMap(gr *graph.Graph) map[graph.TKey]map[graph.TKey]graph.TWeight {
-	flowMap := make(map[graph.TKey]map[graph.TKey]graph.TWeight)
-	for u := range gr.Nodes {
-		flowMap[u] = make(map[graph.TKey]graph.TWeight)
-		for v := range gr.Nodes {
-			flowMap[u][v] = 0
-		}
+// edgeCapacity returns capacity of edge: its weight, or 1 for non-positive weights
+func edgeCapacity(edge *graph.Edge) int64 {
+	if edge.Weight <= 0 {
+		return 1
 	}
-	return flowMap
+	return int64(edge.Weight)
+}
+
+// addVertex adds a vertex to the network and returns its index
+func (net *flowNetwork) addVertex() int {
+	net.adj = append(net.adj, nil)
+	return len(net.adj) - 1
 }
 
-// findAugmentingPath finds a path from source to sink using BFS
-func findAugmentingPath(residualGraph map[graph.TKey]map[graph.TKey]graph.TWeight, source, sink graph.TKey) ([]graph.TKey, map[graph.TKey]graph.TKey) {
-	visited := make(map[graph.TKey]bool)
-	parent := make(map[graph.TKey]graph.TKey)
-	queue := []graph.TKey{source}
-	visited[source] = true
+// addArc adds arc u → v and its twin v → u with given capacities. Returns forward arc
+func (net *flowNetwork) addArc(u, v int, forward, backward int64) int {
+	arc := len(net.to)
+	net.to = append(net.to, v, u)
+	net.residual = append(net.residual, forward, backward)
+	net.capacity = append(net.capacity, forward, backward)
+	net.cost = append(net.cost, 0, 0)
+	net.adj[u] = append(net.adj[u], arc)
+	net.adj[v] = append(net.adj[v], arc+1)
+	return arc
+}
+
+// push sends amount of flow along arc
+func (net *flowNetwork) push(arc int, amount int64) {
+	net.residual[arc] -= amount
+	net.residual[arc^1] += amount
+}
+
+// flow returns net flow along arc, negative if flow goes the opposite way
+func (net *flowNetwork) flow(arc int) int64 {
+	return net.capacity[arc] - net.residual[arc]
+}
+
+// edgeFlow returns net flow along graph edge, negative if flow goes from destination to source
+func (net *flowNetwork) edgeFlow(key graph.TKey) int64 {
+	flow := net.flow(net.edgeArc[key])
+	if arc, ok := net.reverseArc[key]; ok {
+		flow -= net.flow(arc)
+	}
+	return flow
+}
+
+// residualReach marks vertices reachable from s by arcs with positive residual capacity
+func (net *flowNetwork) residualReach(s int) []bool {
+	visited := make([]bool, len(net.adj))
+	visited[s] = true
+	queue := []int{s}
 
 	for len(queue) > 0 {
 		u := queue[0]
 		queue = queue[1:]
 
-		// Check all neighbors with positive residual capacity
-		for v, capacity := range residualGraph[u] {
-			if !visited[v] && capacity > 0 {
-				parent[v] = u
+		for _, arc := range net.adj[u] {
+			if v := net.to[arc]; !visited[v] && net.residual[arc] > 0 {
 				visited[v] = true
 				queue = append(queue, v)
-
-				// If we reached sink, reconstruct path
-				if v == sink {
-					return reconstructPath(parent, source, sink), parent
-				}
 			}
 		}
 	}
 
-	return nil, parent
+	return visited
 }
 
-// reconstructPath builds the path from parent pointers
-func reconstructPath(parent map[graph.TKey]graph.TKey, source, sink graph.TKey) []graph.TKey {
-	path := []graph.TKey{}
-	current := sink
+// sourceSide returns sorted node keys on the source side of minimum cut
+func (net *flowNetwork) sourceSide(s int) []graph.TKey {
+	visited := net.residualReach(s)
 
-	for current != source {
-		path = append([]graph.TKey{current}, path...)
-		current = parent[current]
+	side := []graph.TKey{}
+	for i, key := range net.keys {
+		if visited[i] {
+			side = append(side, key)
+		}
 	}
-	path = append([]graph.TKey{source}, path...)
-
-	return path
+	return side
 }
 
-// findBottleneckCapacity finds the minimum residual capacity along the path
-func findBottleneckCapacity(residualGraph map[graph.TKey]map[graph.TKey]graph.TWeight, path []graph.TKey, parent map[graph.TKey]graph.TKey, sink graph.TKey) graph.TWeight {
-	bottleneck := graph.TWeight(1 << 30) // Large number
-	v := sink
+// findCut returns saturated edges and nodes crossing the minimum cut, which
+// separates vertices reachable from s in residual network from the others
+func (net *flowNetwork) findCut(gr *graph.Graph, s int) ([]FlowEdge, []graph.TKey) {
+	reach := net.residualReach(s)
+	crosses := func(arc int) bool {
+		return net.capacity[arc] > 0 && reach[net.to[arc^1]] && !reach[net.to[arc]]
+	}
+
+	cutEdges := []FlowEdge{}
+	for _, key := range getSortedMapKeys(net.edgeArc) {
+		edge := gr.Edges[key]
+		forward := net.edgeArc[key]
+		backward, ok := net.reverseArc[key]
+		if !ok {
+			backward = forward ^ 1
+		}
 
-	for v != path[0] { // While not at source
-		u := parent[v]
-		if residualGraph[u][v] < bottleneck {
-			bottleneck = residualGraph[u][v]
+		switch {
+		case crosses(forward):
+			capacity := graph.TWeight(net.capacity[forward])
+			cutEdges = append(cutEdges, FlowEdge{EdgeKey: key, Source: edge.Source, Destination: edge.Destination, Capacity: capacity, Flow: capacity})
+		case crosses(backward):
+			capacity := graph.TWeight(net.capacity[backward])
+			cutEdges = append(cutEdges, FlowEdge{EdgeKey: key, Source: edge.Destination, Destination: edge.Source, Capacity: capacity, Flow: capacity})
 		}
-		v = u
 	}
 
-	return bottleneck
+	cutNodes := []graph.TKey{}
+	for _, key := range getSortedMapKeys(net.nodeArc) {
+		if crosses(net.nodeArc[key]) {
+			cutNodes = append(cutNodes, key)
+		}
+	}
+
+	return cutEdges, cutNodes
 }
 
-// updateResidualGraph updates residual capacities and flow after augmenting path
-func updateResidualGraph(residualGraph map[graph.TKey]map[graph.TKey]graph.TWeight, flowMap map[graph.TKey]map[graph.TKey]graph.TWeight, path []graph.TKey, parent map[graph.TKey]graph.TKey, pathFlow graph.TWeight, sink graph.TKey, gr *graph.Graph) {
-	v := sink
+// maxFlow repeatedly augments along shortest path in residual network
+func (edmondsKarpSolver) maxFlow(net *flowNetwork, s, t int) int64 {
+	total := int64(0)
+	parentArc := make([]int, len(net.adj))
 
-	for v != path[0] { // While not at source
-		u := parent[v]
+	for {
+		// Find shortest augmenting path using BFS
+		for i := range parentArc {
+			parentArc[i] = -1
+		}
+		queue := []int{s}
+		for len(queue) > 0 && parentArc[t] == -1 {
+			u := queue[0]
+			queue = queue[1:]
+
+			for _, arc := range net.adj[u] {
+				if v := net.to[arc]; v != s && parentArc[v] == -1 && net.residual[arc] > 0 {
+					parentArc[v] = arc
+					queue = append(queue, v)
+				}
+			}
+		}
 
-		// Update residual capacities
-		residualGraph[u][v] -= pathFlow
-		residualGraph[v][u] += pathFlow
+		if parentArc[t] == -1 {
+			return total // No more augmenting paths
+		}
 
-		// Update flow
-		if hasOriginalEdge(gr, u, v) {
-			flowMap[u][v] += pathFlow
-		} else {
-			// Backward edge - subtract flow
-			flowMap[v][u] -= pathFlow
+		// Find bottleneck capacity and push it along the path
+		bottleneck := int64(math.MaxInt64)
+		for v := t; v != s; v = net.to[parentArc[v]^1] {
+			bottleneck = min(bottleneck, net.residual[parentArc[v]])
+		}
+		for v := t; v != s; v = net.to[parentArc[v]^1] {
+			net.push(parentArc[v], bottleneck)
 		}
 
-		v = u
+		total += bottleneck
 	}
 }
 
-// hasOriginalEdge checks if an edge exists in the original graph
-func hasOriginalEdge(gr *graph.Graph, u, v graph.TKey) bool {
-	for _, edge := range gr.Edges {
-		if edge.Source == u && edge.Destination == v {
-			return true
-		}
-	}
-	return false
+// maxFlow builds BFS level graph and saturates it with blocking flow until sink is unreachable
+func (dinicSolver) maxFlow(net *flowNetwork, s, t int) int64 {
+	return dinicFlow(net, s, t, math.MaxInt64)
 }
 
-// buildFlowEdges creates the list of flow edges from flow map
-func buildFlowEdges(gr *graph.Graph, flowMap map[graph.TKey]map[graph.TKey]graph.TWeight) []FlowEdge {
-	flowEdges := []FlowEdge{}
+// dinicFlow pushes flow from s to t with Dinic's algorithm, but no more than limit
+func dinicFlow(net *flowNetwork, s, t int, limit int64) int64 {
+	total := int64(0)
+	level := make([]int, len(net.adj))
+	next := make([]int, len(net.adj)) // First arc of every vertex not yet known to be useless
 
-	for u := range flowMap {
-		for v := range flowMap[u] {
-			flow := flowMap[u][v]
-			if flow > 0 {
-				// Find original capacity
-				capacity := graph.TWeight(1)
-				for _, edge := range gr.Edges {
-					if edge.Source == u && edge.Destination == v {
-						if edge.Weight > 0 {
-							capacity = edge.Weight
-						}
-						break
-					}
+	for {
+		// Step 1: Level of vertex is its BFS distance from source
+		for i := range level {
+			level[i] = -1
+		}
+		level[s] = 0
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+
+			for _, arc := range net.adj[u] {
+				if v := net.to[arc]; level[v] == -1 && net.residual[arc] > 0 {
+					level[v] = level[u] + 1
+					queue = append(queue, v)
 				}
+			}
+		}
+
+		if level[t] == -1 || total == limit {
+			return total
+		}
 
-				flowEdges = append(flowEdges, FlowEdge{
-					Source:      u,
-					Destination: v,
-					Capacity:    capacity,
-					Flow:        flow,
-				})
+		// Step 2: Blocking flow along arcs going exactly one level down
+		for i := range next {
+			next[i] = 0
+		}
+		for total < limit {
+			pushed := dinicAugment(net, level, next, s, t, limit-total)
+			if pushed == 0 {
+				break
 			}
+			total += pushed
 		}
 	}
+}
 
-	// Sort for consistent output
-	sort.Slice(flowEdges, func(i, j int) bool {
-		if flowEdges[i].Source == flowEdges[j].Source {
-			return flowEdges[i].Destination < flowEdges[j].Destination
+// dinicAugment finds a path to t in level graph and pushes up to limit along it
+func dinicAugment(net *flowNetwork, level, next []int, u, t int, limit int64) int64 {
+	if u == t {
+		return limit
+	}
+
+	for ; next[u] < len(net.adj[u]); next[u]++ {
+		arc := net.adj[u][next[u]]
+		v := net.to[arc]
+		if net.residual[arc] == 0 || level[v] != level[u]+1 {
+			continue
 		}
-		return flowEdges[i].Source < flowEdges[j].Source
-	})
 
-	return flowEdges
-}
+		if pushed := dinicAugment(net, level, next, v, t, min(limit, net.residual[arc])); pushed > 0 {
+			net.push(arc, pushed)
+			return pushed
+		}
+	}
 
-// findMinCut finds the minimum cut (reachable nodes from source in residual graph)
-func findMinCut(residualGraph map[graph.TKey]map[graph.TKey]graph.TWeight, source graph.TKey) []graph.TKey {
-	visited := make(map[graph.TKey]bool)
-	queue := []graph.TKey{source}
-	visited[source] = true
+	return 0
+}
 
+// maxFlow floods the network from source and discharges overflowing vertices,
+// always the highest one first. Excess that cannot reach sink returns to source,
+// so the result is a proper flow, not only a preflow
+func (pushRelabelSolver) maxFlow(net *flowNetwork, s, t int) int64 {
+	n := len(net.adj)
+	height := make([]int, n)
+	excess := make([]int64, n)
+	next := make([]int, n)
+	count := make([]int, 2*n+1)     // Number of vertices at every height
+	buckets := make([][]int, 2*n+1) // Overflowing vertices at every height
+	highest := 0
+
+	// Step 1: Initial heights are residual distances to sink
+	for i := range height {
+		height[i] = n + 1
+	}
+	height[t] = 0
+	queue := []int{t}
 	for len(queue) > 0 {
-		u := queue[0]
+		v := queue[0]
 		queue = queue[1:]
 
-		for v, capacity := range residualGraph[u] {
-			if !visited[v] && capacity > 0 {
-				visited[v] = true
-				queue = append(queue, v)
+		for _, arc := range net.adj[v] {
+			if u := net.to[arc]; u != s && height[u] == n+1 && net.residual[arc^1] > 0 {
+				height[u] = height[v] + 1
+				queue = append(queue, u)
+			}
+		}
+	}
+	height[s] = n
+	for _, h := range height {
+		count[h]++
+	}
+
+	activate := func(v int) {
+		if v == s || v == t {
+			return
+		}
+		buckets[height[v]] = append(buckets[height[v]], v)
+		highest = max(highest, height[v])
+	}
+
+	send := func(arc int, amount int64) {
+		u, v := net.to[arc^1], net.to[arc]
+		net.push(arc, amount)
+		if excess[v] == 0 {
+			activate(v)
+		}
+		excess[u] -= amount
+		excess[v] += amount
+	}
+
+	relabel := func(u int) {
+		old := height[u]
+		newHeight := 2 * n
+		for _, arc := range net.adj[u] {
+			if net.residual[arc] > 0 {
+				newHeight = min(newHeight, height[net.to[arc]]+1)
+			}
+		}
+		count[old]--
+		height[u] = newHeight
+		count[newHeight]++
+		next[u] = 0
+
+		// Gap heuristic: nobody is left at old height, so vertices above it
+		// cannot reach sink anymore and may be lifted above source at once
+		if count[old] == 0 && old < n {
+			for v := range height {
+				if old < height[v] && height[v] < n {
+					count[height[v]]--
+					height[v] = n + 1
+					count[n+1]++
+					next[v] = 0
+				}
 			}
 		}
 	}
 
-	// Convert visited map to sorted slice
-	minCut := []graph.TKey{}
-	for node := range visited {
-		minCut = append(minCut, node)
+	// Step 2: Saturate every arc leaving source
+	for _, arc := range net.adj[s] {
+		if net.residual[arc] > 0 {
+			send(arc, net.residual[arc])
+		}
+	}
+
+	// Step 3: Discharge the highest overflowing vertex until none is left
+	for highest >= 0 {
+		bucket := buckets[highest]
+		if len(bucket) == 0 {
+			highest--
+			continue
+		}
+		u := bucket[len(bucket)-1]
+		buckets[highest] = bucket[:len(bucket)-1]
+
+		if height[u] != highest {
+			activate(u) // Lifted by gap heuristic while waiting
+			continue
+		}
+
+		for excess[u] > 0 {
+			if next[u] == len(net.adj[u]) {
+				relabel(u)
+				continue
+			}
+
+			arc := net.adj[u][next[u]]
+			if net.residual[arc] > 0 && height[u] == height[net.to[arc]]+1 {
+				send(arc, min(excess[u], net.residual[arc]))
+			} else {
+				next[u]++
+			}
+		}
 	}
-	sort.Slice(minCut, func(i, j int) bool { return minCut[i] < minCut[j] })
 
-	return minCut
+	return excess[t]
+}
+
+// buildFlowEdges creates the list of edges carrying flow, in the direction of flow
+func buildFlowEdges(gr *graph.Graph, net *flowNetwork) []FlowEdge {
+	flowEdges := []FlowEdge{}
+
+	for _, key := range getSortedMapKeys(net.edgeArc) {
+		edge := gr.Edges[key]
+		arc := net.edgeArc[key]
+		flowEdge := FlowEdge{
+			EdgeKey:     key,
+			Source:      edge.Source,
+			Destination: edge.Destination,
+			Capacity:    graph.TWeight(net.capacity[arc]),
+			Flow:        graph.TWeight(net.edgeFlow(key)),
+			Cost:        graph.TWeight(net.cost[arc]),
+		}
+
+		if flowEdge.Flow < 0 {
+			flowEdge.Source, flowEdge.Destination = edge.Destination, edge.Source
+			flowEdge.Flow = -flowEdge.Flow
+		}
+		if flowEdge.Flow > 0 {
+			flowEdges = append(flowEdges, flowEdge)
+		}
+	}
+
+	// Sort for consistent output
+	sort.SliceStable(flowEdges, func(i, j int) bool {
+		if flowEdges[i].Source == flowEdges[j].Source {
+			return flowEdges[i].Destination < flowEdges[j].Destination
+		}
+		return flowEdges[i].Source < flowEdges[j].Source
+	})
+
+	return flowEdges
+}
+
+// describeMaxFlowAlgorithm returns algorithm name with a short explanation
+func describeMaxFlowAlgorithm(algorithm string) string {
+	switch MaxFlowAlgorithm(algorithm) {
+	case MaxFlowEdmondsKarp:
+		return "Edmonds-Karp (BFS-based Ford-Fulkerson)"
+	case MaxFlowDinic:
+		return "Dinic (blocking flows in level graph)"
+	case MaxFlowPushRelabel:
+		return "Push-Relabel (highest label, gap heuristic)"
+	}
+	return algorithm
 }
 
 // FormatMaxFlowResult creates a formatted string representation
@@ -291,21 +727,23 @@ func (result *MaxFlowResult) FormatMaxFlowResult(gr *graph.Graph) string {
 	var sb strings.Builder
 
 	sb.WriteString("MAXIMUM FLOW ANALYSIS\n\n")
-	sb.WriteString("Algorithm: Edmonds-Karp (BFS-based Ford-Fulkerson)\n")
-	sb.WriteString(fmt.Sprintf("Source: %d", result.Source))
-	if node, _ := gr.GetNodeByKey(result.Source); node != nil && node.Label != "" {
-		sb.WriteString(fmt.Sprintf(" (%s)", node.Label))
+	sb.WriteString(fmt.Sprintf("Algorithm: %s\n", describeMaxFlowAlgorithm(result.Algorithm)))
+	if len(result.Sources) > 1 {
+		sb.WriteString(fmt.Sprintf("Sources: %s\n", formatKeyList(result.Sources)))
+	} else {
+		sb.WriteString(fmt.Sprintf("Source: %s\n", formatNodeName(gr, result.Source)))
 	}
-	sb.WriteString(fmt.Sprintf("\nSink: %d", result.Sink))
-	if node, _ := gr.GetNodeByKey(result.Sink); node != nil && node.Label != "" {
-		sb.WriteString(fmt.Sprintf(" (%s)", node.Label))
+	if len(result.Sinks) > 1 {
+		sb.WriteString(fmt.Sprintf("Sinks: %s\n", formatKeyList(result.Sinks)))
+	} else {
+		sb.WriteString(fmt.Sprintf("Sink: %s\n", formatNodeName(gr, result.Sink)))
 	}
-	sb.WriteString(fmt.Sprintf("\nMaximum Flow Value: %d\n\n", result.MaxFlowValue))
+	sb.WriteString(fmt.Sprintf("Maximum Flow Value: %d\n\n", result.MaxFlowValue))
 
 	sb.WriteString("FLOW DISTRIBUTION:\n")
-	sb.WriteString(strings.Repeat("─", 60) + "\n")
-	sb.WriteString(fmt.Sprintf("%-8s %-8s %-12s %-12s %-12s\n", "From", "To", "Capacity", "Flow", "Utilization"))
-	sb.WriteString(fmt.Sprintf("%-8s %-8s %-12s %-12s %-12s\n", "────", "──", "────────", "────", "────────────"))
+	sb.WriteString(strings.Repeat("─", 68) + "\n")
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s %-12s %-12s\n", "Edge", "From", "To", "Capacity", "Flow", "Utilization"))
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s %-12s %-12s\n", "────", "────", "──", "────────", "────", "────────────"))
 
 	totalCapacity := graph.TWeight(0)
 	totalFlow := graph.TWeight(0)
@@ -326,8 +764,8 @@ func (result *MaxFlowResult) FormatMaxFlowResult(gr *graph.Graph) string {
 			utilization = fmt.Sprintf("%.1f%%", float64(edge.Flow)*100/float64(edge.Capacity))
 		}
 
-		sb.WriteString(fmt.Sprintf("%-8s %-8s %-12d %-12d %-12s\n",
-			fromLabel, toLabel, edge.Capacity, edge.Flow, utilization))
+		sb.WriteString(fmt.Sprintf("%-8d %-8s %-8s %-12d %-12d %-12s\n",
+			edge.EdgeKey, fromLabel, toLabel, edge.Capacity, edge.Flow, utilization))
 
 		totalCapacity += edge.Capacity
 		totalFlow += edge.Flow
@@ -341,7 +779,21 @@ func (result *MaxFlowResult) FormatMaxFlowResult(gr *graph.Graph) string {
 		sb.WriteString(fmt.Sprintf("Flow efficiency: %.1f%%\n", float64(totalFlow)*100/float64(totalCapacity)))
 	}
 
-	sb.WriteString(fmt.Sprintf("\nMINIMUM CUT (%d nodes):\n", len(result.MinCut)))
+	if result.Decomposition != nil {
+		sb.WriteString("\nFLOW DECOMPOSITION:\n")
+		sb.WriteString(strings.Repeat("─", 40) + "\n")
+		sb.WriteString(result.Decomposition.FormatFlowDecomposition())
+	}
+
+	sb.WriteString(fmt.Sprintf("\nMINIMUM CUT EDGES (%d edges):\n", len(result.CutEdges)))
+	for _, edge := range result.CutEdges {
+		sb.WriteString(fmt.Sprintf("Edge %d: %d → %d, capacity %d\n", edge.EdgeKey, edge.Source, edge.Destination, edge.Capacity))
+	}
+	if len(result.CutNodes) > 0 {
+		sb.WriteString(fmt.Sprintf("Saturated nodes in cut: %s\n", formatKeyList(result.CutNodes)))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nSOURCE SIDE OF MINIMUM CUT (%d nodes):\n", len(result.MinCut)))
 	for i, node := range result.MinCut {
 		nodeLabel := fmt.Sprintf("%d", node)
 		if nodeObj, _ := gr.GetNodeByKey(node); nodeObj != nil && nodeObj.Label != "" {
@@ -0,0 +1,431 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find minimum-cost flow from source to sink
+ *
+ * Every edge has a capacity and a cost of sending one unit of flow along it.
+ * Both are read from configurable edge fields: "weight" is the edge weight,
+ * "unit" is 1 for every edge, and any other name is an edge attribute. The
+ * amount of flow is either given (target flow) or maximum possible, and among
+ * all flows of that amount the cheapest one is found.
+ *
+ * Successive Shortest Paths - augment along the cheapest path of residual
+ * network. Dijkstra runs on reduced costs c(u, v) + p(u) - p(v), which stay
+ * non-negative thanks to potentials p, just like in Johnson's algorithm.
+ * Time Complexity: O(F * E log V), where F is the flow value.
+ *
+ * Cost Scaling (Goldberg-Tarjan) - any flow of required amount is found first
+ * by Dinic, then it is made cheaper by push-relabel refinements: each round
+ * makes flow ε-optimal for smaller ε, until ε is small enough to be optimal.
+ * Time Complexity: O(V^2 * E * log(V * C)), does not depend on flow value and
+ * also copes with negative cost cycles.
+ */
+
+// Edge fields understood besides attribute names
+const (
+	EdgeFieldWeight = "weight" // Edge weight
+	EdgeFieldUnit   = "unit"   // 1 for every edge
+)
+
+// costScalingFactor is how many times ε shrinks between refinements
+const costScalingFactor = 8
+
+// MinCostFlowOptions configures minimum-cost flow calculation
+type MinCostFlowOptions struct {
+	CapacityField string        // Field with edge capacity, edge weight by default
+	CostField     string        // Field with cost of unit of flow, "cost" attribute by default
+	TargetFlow    graph.TWeight // Amount of flow to send, 0 means as much as possible
+	CostScaling   bool          // Use cost scaling instead of successive shortest paths
+}
+
+func WithCapacityField(field string) graph.Option[MinCostFlowOptions] {
+	return func(opts *MinCostFlowOptions) {
+		opts.CapacityField = field
+	}
+}
+
+func WithCostField(field string) graph.Option[MinCostFlowOptions] {
+	return func(opts *MinCostFlowOptions) {
+		opts.CostField = field
+	}
+}
+
+func WithTargetFlow(amount graph.TWeight) graph.Option[MinCostFlowOptions] {
+	return func(opts *MinCostFlowOptions) {
+		opts.TargetFlow = amount
+	}
+}
+
+func WithCostScaling(costScaling bool) graph.Option[MinCostFlowOptions] {
+	return func(opts *MinCostFlowOptions) {
+		opts.CostScaling = costScaling
+	}
+}
+
+// MinCostFlowResult contains the result of minimum-cost flow calculation
+type MinCostFlowResult struct {
+	Source          graph.TKey    `json:"source"`
+	Sink            graph.TKey    `json:"sink"`
+	FlowValue       graph.TWeight `json:"flow_value"`
+	TotalCost       graph.TWeight `json:"total_cost"`
+	TargetFlow      graph.TWeight `json:"target_flow"`
+	IsTargetReached bool          `json:"is_target_reached"`
+	CapacityField   string        `json:"capacity_field"`
+	CostField       string        `json:"cost_field"`
+	FlowEdges       []FlowEdge    `json:"flow_edges"`
+	Algorithm       string        `json:"algorithm"`
+	Message         string        `json:"message"`
+}
+
+// FindMinCostFlow finds the cheapest flow from source to sink. Without target
+// flow it is the cheapest among maximum flows
+func FindMinCostFlow(gr *graph.Graph, source, sink graph.TKey, options ...graph.Option[MinCostFlowOptions]) (*MinCostFlowResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	opts := MinCostFlowOptions{CapacityField: EdgeFieldWeight, CostField: "cost"}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if err := validateFlowTerminals(gr, source, sink); err != nil {
+		return nil, err
+	}
+	if opts.TargetFlow < 0 {
+		return nil, fmt.Errorf("target flow cannot be negative, got %d", opts.TargetFlow)
+	}
+
+	net, err := newCostFlowNetwork(gr, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(math.MaxInt64)
+	if opts.TargetFlow > 0 {
+		limit = int64(opts.TargetFlow)
+	}
+
+	s, t := net.index[source], net.index[sink]
+	algorithm := "Successive Shortest Paths"
+	var flow int64
+	if opts.CostScaling {
+		algorithm = "Cost Scaling"
+		flow = dinicFlow(net, s, t, limit)
+		costScalingRefine(net)
+	} else if flow, err = successiveShortestPaths(net, s, t, limit); err != nil {
+		return nil, err
+	}
+
+	result := &MinCostFlowResult{
+		Source:          source,
+		Sink:            sink,
+		FlowValue:       graph.TWeight(flow),
+		TargetFlow:      opts.TargetFlow,
+		IsTargetReached: opts.TargetFlow == 0 || flow == limit,
+		CapacityField:   opts.CapacityField,
+		CostField:       opts.CostField,
+		FlowEdges:       buildFlowEdges(gr, net),
+		Algorithm:       algorithm,
+	}
+
+	// Every arc of cost network carries flow of a real edge
+	for arc := 0; arc < len(net.to); arc += 2 {
+		result.TotalCost += graph.TWeight(net.flow(arc) * net.cost[arc])
+	}
+
+	if result.IsTargetReached {
+		result.Message = fmt.Sprintf("Flow %d from %d to %d costs %d", flow, source, sink, result.TotalCost)
+	} else {
+		result.Message = fmt.Sprintf("Only %d of %d units can flow from %d to %d, cost %d",
+			flow, opts.TargetFlow, source, sink, result.TotalCost)
+	}
+
+	return result, nil
+}
+
+// newCostFlowNetwork builds network with capacities and costs read from edge fields.
+// Undirected edge gives two arcs with the same cost, one for each direction
+func newCostFlowNetwork(gr *graph.Graph, opts MinCostFlowOptions) (*flowNetwork, error) {
+	net := &flowNetwork{
+		index:      make(map[graph.TKey]int, len(gr.Nodes)),
+		edgeArc:    make(map[graph.TKey]int, len(gr.Edges)),
+		reverseArc: make(map[graph.TKey]int),
+	}
+
+	for _, key := range getSortedKeys(gr.Nodes) {
+		net.index[key] = net.addVertex()
+		net.keys = append(net.keys, key)
+	}
+
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		if edge.Source == edge.Destination {
+			continue // Loop never carries flow
+		}
+
+		capacity, err := getFlowCapacity(edge, opts.CapacityField)
+		if err != nil {
+			return nil, err
+		}
+		cost, err := getEdgeField(edge, opts.CostField)
+		if err != nil {
+			return nil, err
+		}
+		if cost < 0 && !gr.Options.IsDirected {
+			return nil, fmt.Errorf("undirected edge %d has negative cost %d", key, cost)
+		}
+
+		u, v := net.index[edge.Source], net.index[edge.Destination]
+		net.edgeArc[key] = net.addCostArc(u, v, capacity, cost)
+		if !gr.Options.IsDirected {
+			net.reverseArc[key] = net.addCostArc(v, u, capacity, cost)
+		}
+	}
+
+	return net, nil
+}
+
+// addCostArc adds arc u → v with capacity and cost, its twin gets the opposite cost
+func (net *flowNetwork) addCostArc(u, v int, capacity, cost int64) int {
+	arc := net.addArc(u, v, capacity, 0)
+	net.cost[arc], net.cost[arc^1] = cost, -cost
+	return arc
+}
+
+// getEdgeField reads a numeric field of edge: weight, unit or attribute
+func getEdgeField(edge *graph.Edge, field string) (int64, error) {
+	switch field {
+	case EdgeFieldWeight:
+		return int64(edge.Weight), nil
+	case EdgeFieldUnit:
+		return 1, nil
+	}
+
+	value, ok := edge.GetAttribute(field)
+	if !ok {
+		return 0, fmt.Errorf("edge %d has no attribute %q", edge.Key, field)
+	}
+	return int64(value), nil
+}
+
+// getFlowCapacity reads capacity of edge. Weight follows FindMaxFlow and counts
+// non-positive values as 1, other fields are taken as is and must not be negative
+func getFlowCapacity(edge *graph.Edge, field string) (int64, error) {
+	if field == EdgeFieldWeight {
+		return edgeCapacity(edge), nil
+	}
+
+	capacity, err := getEdgeField(edge, field)
+	if err != nil {
+		return 0, err
+	}
+	if capacity < 0 {
+		return 0, fmt.Errorf("edge %d has negative capacity %d", edge.Key, capacity)
+	}
+	return capacity, nil
+}
+
+// successiveShortestPaths pushes up to limit units from s to t along cheapest paths
+func successiveShortestPaths(net *flowNetwork, s, t int, limit int64) (int64, error) {
+	n := len(net.adj)
+
+	// Step 1: Initial potentials by Bellman-Ford from virtual source, as costs may be negative
+	potential := make([]int64, n)
+	for round := 0; ; round++ {
+		changed := false
+		for arc := range net.to {
+			u, v := net.to[arc^1], net.to[arc]
+			if net.residual[arc] > 0 && potential[u]+net.cost[arc] < potential[v] {
+				potential[v] = potential[u] + net.cost[arc]
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+		if round == n {
+			return 0, fmt.Errorf("network contains a negative cost cycle, use cost scaling instead")
+		}
+	}
+
+	// Step 2: Augment along cheapest paths found by Dijkstra on reduced costs
+	dist := make([]int64, n)
+	parentArc := make([]int, n)
+	flow := int64(0)
+
+	for flow < limit {
+		for i := range dist {
+			dist[i], parentArc[i] = math.MaxInt64, -1
+		}
+		dist[s] = 0
+		pq := &distanceHeap{{vertex: graph.TKey(s), dist: 0}}
+
+		for pq.Len() > 0 {
+			item := heap.Pop(pq).(distanceItem)
+			u := int(item.vertex)
+			if item.dist > dist[u] {
+				continue // Outdated entry
+			}
+
+			for _, arc := range net.adj[u] {
+				if net.residual[arc] == 0 {
+					continue
+				}
+				v := net.to[arc]
+				if newDist := item.dist + net.cost[arc] + potential[u] - potential[v]; newDist < dist[v] {
+					dist[v], parentArc[v] = newDist, arc
+					heap.Push(pq, distanceItem{vertex: graph.TKey(v), dist: newDist})
+				}
+			}
+		}
+
+		if dist[t] == math.MaxInt64 {
+			break // Sink is unreachable, flow is maximum
+		}
+
+		// Shift potentials, so reduced costs stay non-negative in the new residual network
+		for v := range potential {
+			if dist[v] != math.MaxInt64 {
+				potential[v] += dist[v]
+			}
+		}
+
+		amount := limit - flow
+		for v := t; v != s; v = net.to[parentArc[v]^1] {
+			amount = min(amount, net.residual[parentArc[v]])
+		}
+		for v := t; v != s; v = net.to[parentArc[v]^1] {
+			net.push(parentArc[v], amount)
+		}
+		flow += amount
+	}
+
+	return flow, nil
+}
+
+// costScalingRefine turns any flow into the cheapest flow of the same amount.
+// It looks for minimum-cost circulation in residual network, which does not
+// change flow value. Costs are multiplied by V + 1, so 1-optimal flow is optimal
+func costScalingRefine(net *flowNetwork) {
+	n := len(net.adj)
+	scale := int64(n + 1)
+
+	cost := make([]int64, len(net.cost))
+	eps := int64(0)
+	for arc, c := range net.cost {
+		cost[arc] = c * scale
+		eps = max(eps, cost[arc])
+	}
+
+	price := make([]int64, n)
+	excess := make([]int64, n)
+	next := make([]int, n)
+	reduced := func(arc int) int64 {
+		return cost[arc] + price[net.to[arc^1]] - price[net.to[arc]]
+	}
+
+	for eps > 1 {
+		eps = max(1, eps/costScalingFactor)
+
+		// Step 1: Saturate every arc with negative reduced cost. Now any residual
+		// arc is cheap enough, but some vertices have excess or deficit
+		for arc := range net.to {
+			if amount := net.residual[arc]; amount > 0 && reduced(arc) < 0 {
+				net.push(arc, amount)
+				excess[net.to[arc^1]] -= amount
+				excess[net.to[arc]] += amount
+			}
+		}
+
+		// Step 2: Push excess along arcs with negative reduced cost, lowering
+		// price of vertex when it has none
+		queue := []int{}
+		for v := range excess {
+			next[v] = 0
+			if excess[v] > 0 {
+				queue = append(queue, v)
+			}
+		}
+
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+
+			for excess[u] > 0 {
+				if next[u] == len(net.adj[u]) {
+					best := int64(math.MinInt64)
+					for _, arc := range net.adj[u] {
+						if net.residual[arc] > 0 {
+							best = max(best, price[net.to[arc]]-cost[arc])
+						}
+					}
+					price[u] = best - eps
+					next[u] = 0
+					continue
+				}
+
+				arc := net.adj[u][next[u]]
+				if net.residual[arc] == 0 || reduced(arc) >= 0 {
+					next[u]++
+					continue
+				}
+
+				v := net.to[arc]
+				amount := min(excess[u], net.residual[arc])
+				net.push(arc, amount)
+				if excess[v] <= 0 && excess[v]+amount > 0 {
+					queue = append(queue, v)
+				}
+				excess[u] -= amount
+				excess[v] += amount
+			}
+		}
+	}
+}
+
+// FormatMinCostFlowResult creates a formatted string representation
+func (result *MinCostFlowResult) FormatMinCostFlowResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("MINIMUM-COST FLOW\n\n")
+	sb.WriteString(fmt.Sprintf("Algorithm: %s\n", result.Algorithm))
+	sb.WriteString(fmt.Sprintf("Source: %s\n", formatNodeName(gr, result.Source)))
+	sb.WriteString(fmt.Sprintf("Sink: %s\n", formatNodeName(gr, result.Sink)))
+	sb.WriteString(fmt.Sprintf("Capacity from: %s, cost from: %s\n", result.CapacityField, result.CostField))
+	if result.TargetFlow > 0 {
+		sb.WriteString(fmt.Sprintf("Target flow: %d (reached: %v)\n", result.TargetFlow, result.IsTargetReached))
+	} else {
+		sb.WriteString("Target flow: maximum\n")
+	}
+	sb.WriteString(fmt.Sprintf("Flow value: %d\n", result.FlowValue))
+	sb.WriteString(fmt.Sprintf("Total cost: %d\n\n", result.TotalCost))
+
+	sb.WriteString("FLOW DISTRIBUTION:\n")
+	sb.WriteString(strings.Repeat("─", 68) + "\n")
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-10s %-10s %-10s %-10s\n", "Edge", "From", "To", "Capacity", "Flow", "Cost", "Total"))
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-10s %-10s %-10s %-10s\n", "────", "────", "──", "────────", "────", "────", "─────"))
+
+	for _, edge := range result.FlowEdges {
+		sb.WriteString(fmt.Sprintf("%-8d %-8d %-8d %-10d %-10d %-10d %-10d\n",
+			edge.EdgeKey, edge.Source, edge.Destination, edge.Capacity, edge.Flow, edge.Cost, edge.Flow*edge.Cost))
+	}
+
+	sb.WriteString("\n" + result.Message + "\n")
+
+	return sb.String()
+}
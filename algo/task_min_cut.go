@@ -0,0 +1,319 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Find global minimum cut and Gomory-Hu tree of undirected graph
+ *
+ * Edge weights are capacities, the same way as in FindMaxFlow: zero and
+ * negative weights count as 1.
+ *
+ * Stoer-Wagner Algorithm - global minimum cut without choosing source and sink.
+ * Each phase orders vertices by maximum adjacency: the next vertex is the one
+ * most tightly connected to already taken ones. Two last vertices s and t of
+ * this order are separated by cut of weight w(t), and this is the minimum s-t
+ * cut. So either w(t) is the answer, or s and t are on the same side, and may
+ * be merged. Time Complexity: O(V * E log V)
+ *
+ * Gomory-Hu Tree - weighted tree on the same vertices, such that minimum cut
+ * between any u and v equals the lightest edge on the tree path u → v. Built
+ * by Gusfield's algorithm with V - 1 max flow runs and no contractions.
+ */
+
+// StoerWagnerResult represents global minimum cut of the graph
+type StoerWagnerResult struct {
+	Value    graph.TWeight // Total capacity of cut edges
+	Side     []graph.TKey  // Vertices of the smaller side of the cut
+	CutEdges []graph.TKey  // Keys of edges between two sides
+	Message  string        // Status message
+}
+
+// FindGlobalMinCut finds minimum cut of undirected graph using Stoer-Wagner algorithm
+func FindGlobalMinCut(gr *graph.Graph) (*StoerWagnerResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if gr.Options.IsDirected {
+		return nil, graph.ThrowGraphDirected()
+	}
+	if len(gr.Nodes) < 2 {
+		return nil, fmt.Errorf("minimum cut needs at least 2 nodes, graph has %d", len(gr.Nodes))
+	}
+
+	// Vertices are merged as algorithm goes: every one keeps merged weights and members
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	members := make([][]graph.TKey, len(keys))
+	adj := make([]map[int]int64, len(keys))
+	for i, key := range keys {
+		index[key] = i
+		members[i] = []graph.TKey{key}
+		adj[i] = make(map[int]int64)
+	}
+	for _, edge := range gr.Edges {
+		u, v := index[edge.Source], index[edge.Destination]
+		if u != v {
+			adj[u][v] += edgeCapacity(edge)
+			adj[v][u] += edgeCapacity(edge)
+		}
+	}
+
+	active := make([]int, len(keys))
+	for i := range active {
+		active[i] = i
+	}
+
+	best := int64(math.MaxInt64)
+	var bestSide []graph.TKey
+
+	for len(active) > 1 {
+		// Step 1: Maximum adjacency order, the heap keeps negated weights
+		weight := make(map[int]int64, len(active))
+		added := make(map[int]bool, len(active))
+		pq := &distanceHeap{}
+		for _, v := range active {
+			heap.Push(pq, distanceItem{vertex: graph.TKey(v), dist: 0})
+		}
+
+		prev, last := -1, -1
+		for range active {
+			var v int
+			for {
+				item := heap.Pop(pq).(distanceItem)
+				v = int(item.vertex)
+				if !added[v] && -item.dist == weight[v] {
+					break // Skip outdated entries
+				}
+			}
+
+			added[v] = true
+			prev, last = last, v
+			for u, w := range adj[v] {
+				if !added[u] {
+					weight[u] += w
+					heap.Push(pq, distanceItem{vertex: graph.TKey(u), dist: -weight[u]})
+				}
+			}
+		}
+
+		// Step 2: Cut of the phase separates the last vertex from everything else
+		if weight[last] < best {
+			best = weight[last]
+			bestSide = append([]graph.TKey{}, members[last]...)
+		}
+
+		// Step 3: Merge two last vertices
+		for u, w := range adj[last] {
+			delete(adj[u], last)
+			if u != prev {
+				adj[prev][u] += w
+				adj[u][prev] += w
+			}
+		}
+		members[prev] = append(members[prev], members[last]...)
+		active = removeFromSlice(active, last)
+	}
+
+	// Report the smaller side, it is easier to read
+	if 2*len(bestSide) > len(keys) {
+		inSide := make(map[graph.TKey]bool, len(bestSide))
+		for _, key := range bestSide {
+			inSide[key] = true
+		}
+		bestSide = bestSide[:0]
+		for _, key := range keys {
+			if !inSide[key] {
+				bestSide = append(bestSide, key)
+			}
+		}
+	}
+	sort.Slice(bestSide, func(i, j int) bool { return bestSide[i] < bestSide[j] })
+
+	return &StoerWagnerResult{
+		Value:    graph.TWeight(best),
+		Side:     bestSide,
+		CutEdges: findCrossingEdges(gr, bestSide),
+		Message:  fmt.Sprintf("Global minimum cut has weight %d and separates %d node(s)", best, len(bestSide)),
+	}, nil
+}
+
+// FindGomoryHuTree builds Gomory-Hu tree of undirected graph. Tree has the same
+// nodes, and its edges are weighted by minimum cut values. Options choose max
+// flow algorithm used for V - 1 cuts
+func FindGomoryHuTree(gr *graph.Graph, options ...graph.Option[MaxFlowOptions]) (*graph.Graph, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if gr.Options.IsDirected {
+		return nil, graph.ThrowGraphDirected()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	parent := make([]int, len(keys)) // Every vertex hangs on vertex 0 at first
+	cutValue := make([]graph.TWeight, len(keys))
+
+	for i := 1; i < len(keys); i++ {
+		result, err := FindMaxFlow(gr, keys[i], keys[parent[i]], options...)
+		if err != nil {
+			return nil, err
+		}
+		cutValue[i] = result.MaxFlowValue
+
+		// Vertices on the side of i with the same parent move under i
+		onSide := make(map[graph.TKey]bool, len(result.MinCut))
+		for _, key := range result.MinCut {
+			onSide[key] = true
+		}
+		for j := i + 1; j < len(keys); j++ {
+			if parent[j] == parent[i] && onSide[keys[j]] {
+				parent[j] = i
+			}
+		}
+	}
+
+	tree := graph.MakeGraph(graph.WithGraphDirected(false))
+	for _, key := range keys {
+		tree.AddNode(graph.MakeNode(key, graph.WithNodeLabel(gr.Nodes[key].Label)))
+	}
+	for i := 1; i < len(keys); i++ {
+		edge := graph.MakeEdge(graph.TKey(i), keys[i], keys[parent[i]], graph.WithEdgeWeight(cutValue[i]))
+		if err := tree.AddEdge(edge); err != nil {
+			return nil, err
+		}
+	}
+
+	return tree, nil
+}
+
+// GomoryHuMinCut returns minimum cut value between u and v: the lightest edge on
+// their path in Gomory-Hu tree. Returns 0 for nodes in different components
+func GomoryHuMinCut(tree *graph.Graph, u, v graph.TKey) (graph.TWeight, error) {
+	if _, err := tree.GetNodeByKey(u); err != nil {
+		return 0, fmt.Errorf("node %d does not exist", u)
+	}
+	if _, err := tree.GetNodeByKey(v); err != nil {
+		return 0, fmt.Errorf("node %d does not exist", v)
+	}
+	if u == v {
+		return 0, fmt.Errorf("nodes of the cut must differ")
+	}
+
+	// Walk the tree from u, remembering the lightest edge on the way to every vertex
+	lightest := map[graph.TKey]graph.TWeight{u: math.MaxInt64}
+	incidence := buildUndirectedIncidence(tree)
+	stack := []graph.TKey{u}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		for _, next := range incidence[current] {
+			if _, seen := lightest[next.to]; !seen {
+				lightest[next.to] = min(lightest[current], tree.Edges[next.key].Weight)
+				stack = append(stack, next.to)
+			}
+		}
+	}
+
+	return lightest[v], nil
+}
+
+// findCrossingEdges returns sorted keys of edges with exactly one end in side
+func findCrossingEdges(gr *graph.Graph, side []graph.TKey) []graph.TKey {
+	inSide := make(map[graph.TKey]bool, len(side))
+	for _, key := range side {
+		inSide[key] = true
+	}
+
+	crossing := []graph.TKey{}
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		if inSide[edge.Source] != inSide[edge.Destination] {
+			crossing = append(crossing, key)
+		}
+	}
+	return crossing
+}
+
+// removeFromSlice removes the first occurrence of value
+func removeFromSlice(values []int, value int) []int {
+	for i, v := range values {
+		if v == value {
+			return append(values[:i], values[i+1:]...)
+		}
+	}
+	return values
+}
+
+// FormatStoerWagnerResult creates a formatted string representation
+func (result *StoerWagnerResult) FormatStoerWagnerResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("GLOBAL MINIMUM CUT (Stoer-Wagner)\n\n")
+	sb.WriteString(fmt.Sprintf("Cut weight: %d\n", result.Value))
+	sb.WriteString(fmt.Sprintf("Smaller side (%d nodes): %s\n", len(result.Side), formatKeyList(result.Side)))
+	sb.WriteString(fmt.Sprintf("Other side: %d nodes\n\n", len(gr.Nodes)-len(result.Side)))
+
+	sb.WriteString(fmt.Sprintf("CUT EDGES (%d):\n", len(result.CutEdges)))
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s\n", "Key", "From", "To", "Capacity"))
+	sb.WriteString(fmt.Sprintf("%-8s %-8s %-8s %-12s\n", "────", "────", "──", "────────"))
+	for _, key := range result.CutEdges {
+		edge := gr.Edges[key]
+		sb.WriteString(fmt.Sprintf("%-8d %-8d %-8d %-12d\n", key, edge.Source, edge.Destination, edgeCapacity(edge)))
+	}
+
+	return sb.String()
+}
+
+// FormatGomoryHuTree creates a formatted string representation of Gomory-Hu tree
+func FormatGomoryHuTree(gr, tree *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("GOMORY-HU TREE\n\n")
+	sb.WriteString("Minimum cut between any two nodes is the lightest edge on their tree path\n\n")
+
+	sb.WriteString(fmt.Sprintf("TREE EDGES (%d):\n", len(tree.Edges)))
+	sb.WriteString(fmt.Sprintf("%-20s %-20s %-12s\n", "Node", "Node", "Min Cut"))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for _, key := range getSortedMapKeys(tree.Edges) {
+		edge := tree.Edges[key]
+		sb.WriteString(fmt.Sprintf("%-20s %-20s %-12d\n",
+			formatNodeName(gr, edge.Source), formatNodeName(gr, edge.Destination), edge.Weight))
+	}
+
+	// Small graphs also get the whole table of pairwise cuts
+	keys := getSortedKeys(tree.Nodes)
+	if len(keys) > 1 && len(keys) <= 12 {
+		sb.WriteString("\nALL-PAIRS MINIMUM CUTS:\n")
+		sb.WriteString(fmt.Sprintf("%6s", ""))
+		for _, key := range keys {
+			sb.WriteString(fmt.Sprintf("%6d", key))
+		}
+		sb.WriteString("\n")
+		for _, u := range keys {
+			sb.WriteString(fmt.Sprintf("%6d", u))
+			for _, v := range keys {
+				if u == v {
+					sb.WriteString(fmt.Sprintf("%6s", "-"))
+					continue
+				}
+				value, _ := GomoryHuMinCut(tree, u, v)
+				sb.WriteString(fmt.Sprintf("%6d", value))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
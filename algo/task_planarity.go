@@ -0,0 +1,577 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Check if undirected graph is planar
+ *
+ * Planar graph can be drawn on the plane without crossing edges. Loops and
+ * parallel edges never break planarity, so the test runs on the underlying
+ * simple graph. Graph with V > 2 and E > 3V - 6 is never planar.
+ *
+ * Left-Right Planarity Test (de Fraysseix, Rosenstiehl; Brandes' version):
+ *   1. DFS orients edges, computes lowpoints and nesting depth of every edge
+ *   2. Second DFS visits outgoing edges by nesting depth and keeps a stack of
+ *      conflict pairs: return edges which must be on the left and right side.
+ *      If both sides of a pair conflict with new edge, graph is not planar
+ *   3. Sides of edges are resolved through references, outgoing edges are
+ *      sorted again and third DFS builds the embedding
+ * All phases are O(V + E).
+ *
+ * Embedding - clockwise order of neighbors around every node (rotation
+ * system). Drawing nodes and edges in this order gives no crossings.
+ *
+ * Kuratowski Theorem - graph is planar iff it has no subdivision of K5 or
+ * K3,3. Witness is found by deleting every edge whose removal keeps graph non
+ * planar: what remains is a minimal non planar graph, that is a subdivision.
+ * It is O(E * (V + E)), as planarity is tested once per edge.
+ */
+
+// PlanarityResult represents planarity test with embedding or Kuratowski witness
+type PlanarityResult struct {
+	IsPlanar       bool                        // Graph can be drawn without crossings
+	Embedding      map[graph.TKey][]graph.TKey // Clockwise order of neighbors around every node, when planar
+	Kuratowski     []graph.TKey                // Edge keys of K5 or K3,3 subdivision, when not planar
+	KuratowskiType string                      // "K5" or "K3,3"
+	BranchNodes    []graph.TKey                // Nodes of K5 or K3,3 inside the subdivision
+	Message        string                      // Status message
+}
+
+// lrInterval is a range of return edges on one side, -1 for none
+type lrInterval struct {
+	low, high int
+}
+
+func (interval lrInterval) empty() bool {
+	return interval.low == -1 && interval.high == -1
+}
+
+// lrConflictPair is a pair of intervals that must be on different sides
+type lrConflictPair struct {
+	left, right lrInterval
+}
+
+func (pair *lrConflictPair) swap() {
+	pair.left, pair.right = pair.right, pair.left
+}
+
+// lrPlanarity holds state of left-right planarity test on simple graph with indices
+type lrPlanarity struct {
+	adj        [][]int // Neighbors of every node
+	height     []int   // DFS depth, -1 for unvisited
+	parentEdge []int   // Tree edge into every node, -1 for roots
+	roots      []int
+
+	// Oriented edges, made in first DFS
+	source, target []int
+	oriented       map[[2]int]int
+	lowpt, lowpt2  []int
+	nesting        []int
+	orderedAdj     [][]int // Outgoing edges of every node in nesting order
+
+	// Conflict pairs of the second DFS
+	ref         []int
+	side        []int
+	lowptEdge   []int
+	stackBottom []*lrConflictPair
+	stack       []*lrConflictPair
+
+	// Embedding: clockwise and counterclockwise neighbors in rotation of every node
+	cw, ccw           []map[int]int
+	first             []int
+	leftRef, rightRef []int
+}
+
+// newLRPlanarity prepares test for simple graph on n nodes
+func newLRPlanarity(n int, edges [][2]int) *lrPlanarity {
+	lr := &lrPlanarity{
+		adj:        make([][]int, n),
+		height:     make([]int, n),
+		parentEdge: make([]int, n),
+		oriented:   make(map[[2]int]int, len(edges)),
+		orderedAdj: make([][]int, n),
+	}
+	for _, edge := range edges {
+		lr.adj[edge[0]] = append(lr.adj[edge[0]], edge[1])
+		lr.adj[edge[1]] = append(lr.adj[edge[1]], edge[0])
+	}
+	for v := range n {
+		lr.height[v] = -1
+		lr.parentEdge[v] = -1
+	}
+	return lr
+}
+
+// run tests planarity, building embedding on success if asked
+func (lr *lrPlanarity) run(embed bool) bool {
+	n := len(lr.adj)
+	m := 0
+	for v := range lr.adj {
+		m += len(lr.adj[v])
+	}
+	if n > 2 && m/2 > 3*n-6 {
+		return false
+	}
+
+	for v := range n {
+		if lr.height[v] == -1 {
+			lr.height[v] = 0
+			lr.roots = append(lr.roots, v)
+			lr.orient(v)
+		}
+	}
+
+	for v := range n {
+		lr.sortByNesting(v)
+	}
+	lr.ref = make([]int, len(lr.source))
+	lr.side = make([]int, len(lr.source))
+	lr.lowptEdge = make([]int, len(lr.source))
+	lr.stackBottom = make([]*lrConflictPair, len(lr.source))
+	for e := range lr.source {
+		lr.ref[e] = -1
+		lr.side[e] = 1
+	}
+	for _, root := range lr.roots {
+		if !lr.test(root) {
+			return false
+		}
+	}
+
+	if embed {
+		lr.buildEmbedding()
+	}
+	return true
+}
+
+// orient is the first DFS: orients edges and computes lowpoints and nesting depths
+func (lr *lrPlanarity) orient(v int) {
+	e := lr.parentEdge[v]
+	for _, w := range lr.adj[v] {
+		if _, ok := lr.oriented[[2]int{v, w}]; ok {
+			continue
+		}
+		if _, ok := lr.oriented[[2]int{w, v}]; ok {
+			continue
+		}
+
+		vw := len(lr.source)
+		lr.oriented[[2]int{v, w}] = vw
+		lr.source = append(lr.source, v)
+		lr.target = append(lr.target, w)
+		lr.lowpt = append(lr.lowpt, lr.height[v])
+		lr.lowpt2 = append(lr.lowpt2, lr.height[v])
+		lr.nesting = append(lr.nesting, 0)
+		lr.orderedAdj[v] = append(lr.orderedAdj[v], vw)
+
+		if lr.height[w] == -1 { // Tree edge
+			lr.parentEdge[w] = vw
+			lr.height[w] = lr.height[v] + 1
+			lr.orient(w)
+		} else { // Back edge
+			lr.lowpt[vw] = lr.height[w]
+		}
+
+		lr.nesting[vw] = 2 * lr.lowpt[vw]
+		if lr.lowpt2[vw] < lr.height[v] { // Chordal edge
+			lr.nesting[vw]++
+		}
+
+		if e != -1 {
+			switch {
+			case lr.lowpt[vw] < lr.lowpt[e]:
+				lr.lowpt2[e] = min(lr.lowpt[e], lr.lowpt2[vw])
+				lr.lowpt[e] = lr.lowpt[vw]
+			case lr.lowpt[vw] > lr.lowpt[e]:
+				lr.lowpt2[e] = min(lr.lowpt2[e], lr.lowpt[vw])
+			default:
+				lr.lowpt2[e] = min(lr.lowpt2[e], lr.lowpt2[vw])
+			}
+		}
+	}
+}
+
+// sortByNesting orders outgoing edges of v by nesting depth
+func (lr *lrPlanarity) sortByNesting(v int) {
+	slices.SortStableFunc(lr.orderedAdj[v], func(a, b int) int { return lr.nesting[a] - lr.nesting[b] })
+}
+
+// top returns top conflict pair, nil for empty stack
+func (lr *lrPlanarity) top() *lrConflictPair {
+	if len(lr.stack) == 0 {
+		return nil
+	}
+	return lr.stack[len(lr.stack)-1]
+}
+
+func (lr *lrPlanarity) pop() *lrConflictPair {
+	pair := lr.top()
+	lr.stack = lr.stack[:len(lr.stack)-1]
+	return pair
+}
+
+// conflicting checks if interval has return edge higher than lowpoint of edge b
+func (lr *lrPlanarity) conflicting(interval lrInterval, b int) bool {
+	return !interval.empty() && lr.lowpt[interval.high] > lr.lowpt[b]
+}
+
+// lowest returns the lowest lowpoint of return edges in the pair
+func (lr *lrPlanarity) lowest(pair *lrConflictPair) int {
+	if pair.left.empty() {
+		return lr.lowpt[pair.right.low]
+	}
+	if pair.right.empty() {
+		return lr.lowpt[pair.left.low]
+	}
+	return min(lr.lowpt[pair.left.low], lr.lowpt[pair.right.low])
+}
+
+// test is the second DFS, false when conflict pairs cannot be resolved
+func (lr *lrPlanarity) test(v int) bool {
+	e := lr.parentEdge[v]
+	for _, ei := range lr.orderedAdj[v] {
+		w := lr.target[ei]
+		lr.stackBottom[ei] = lr.top()
+		if ei == lr.parentEdge[w] { // Tree edge
+			if !lr.test(w) {
+				return false
+			}
+		} else { // Back edge
+			lr.lowptEdge[ei] = ei
+			lr.stack = append(lr.stack, &lrConflictPair{left: lrInterval{-1, -1}, right: lrInterval{ei, ei}})
+		}
+
+		// Integrate new return edges
+		if lr.lowpt[ei] < lr.height[v] {
+			if ei == lr.orderedAdj[v][0] {
+				lr.lowptEdge[e] = lr.lowptEdge[ei]
+			} else if !lr.addConstraints(ei, e) {
+				return false
+			}
+		}
+	}
+
+	if e != -1 {
+		lr.removeBackEdges(e)
+	}
+	return true
+}
+
+// addConstraints merges return edges of ei with conflicting return edges of its siblings
+func (lr *lrPlanarity) addConstraints(ei, e int) bool {
+	pair := &lrConflictPair{left: lrInterval{-1, -1}, right: lrInterval{-1, -1}}
+
+	// Return edges of ei go to the right
+	for {
+		q := lr.pop()
+		if !q.left.empty() {
+			q.swap()
+		}
+		if !q.left.empty() {
+			return false
+		}
+		if lr.lowpt[q.right.low] > lr.lowpt[e] {
+			if pair.right.empty() {
+				pair.right = q.right
+			} else {
+				lr.ref[pair.right.low] = q.right.high
+			}
+			pair.right.low = q.right.low
+		} else {
+			lr.ref[q.right.low] = lr.lowptEdge[e]
+		}
+		if lr.top() == lr.stackBottom[ei] {
+			break
+		}
+	}
+
+	// Conflicting return edges of previous siblings go to the left
+	for top := lr.top(); top != nil && (lr.conflicting(top.left, ei) || lr.conflicting(top.right, ei)); top = lr.top() {
+		q := lr.pop()
+		if lr.conflicting(q.right, ei) {
+			q.swap()
+		}
+		if lr.conflicting(q.right, ei) {
+			return false
+		}
+		if pair.right.low != -1 {
+			lr.ref[pair.right.low] = q.right.high
+		}
+		if q.right.low != -1 {
+			pair.right.low = q.right.low
+		}
+		if pair.left.empty() {
+			pair.left = q.left
+		} else {
+			lr.ref[pair.left.low] = q.left.high
+		}
+		pair.left.low = q.left.low
+	}
+
+	if !pair.left.empty() || !pair.right.empty() {
+		lr.stack = append(lr.stack, pair)
+	}
+	return true
+}
+
+// removeBackEdges trims return edges ending at parent of e and sets reference of e
+func (lr *lrPlanarity) removeBackEdges(e int) {
+	u := lr.source[e]
+
+	// Drop whole conflict pairs returning to u
+	for len(lr.stack) > 0 && lr.lowest(lr.top()) == lr.height[u] {
+		pair := lr.pop()
+		if pair.left.low != -1 {
+			lr.side[pair.left.low] = -1
+		}
+	}
+
+	// One more pair may return to u partly
+	if len(lr.stack) > 0 {
+		pair := lr.pop()
+		for pair.left.high != -1 && lr.target[pair.left.high] == u {
+			pair.left.high = lr.ref[pair.left.high]
+		}
+		if pair.left.high == -1 && pair.left.low != -1 {
+			lr.ref[pair.left.low] = pair.right.low
+			lr.side[pair.left.low] = -1
+			pair.left.low = -1
+		}
+		for pair.right.high != -1 && lr.target[pair.right.high] == u {
+			pair.right.high = lr.ref[pair.right.high]
+		}
+		if pair.right.high == -1 && pair.right.low != -1 {
+			lr.ref[pair.right.low] = pair.left.low
+			lr.side[pair.right.low] = -1
+			pair.right.low = -1
+		}
+		lr.stack = append(lr.stack, pair)
+	}
+
+	// Side of e is side of its highest return edge
+	if lr.lowpt[e] < lr.height[u] && len(lr.stack) > 0 {
+		hl, hr := lr.top().left.high, lr.top().right.high
+		if hl != -1 && (hr == -1 || lr.lowpt[hl] > lr.lowpt[hr]) {
+			lr.ref[e] = hl
+		} else {
+			lr.ref[e] = hr
+		}
+	}
+}
+
+// sign resolves side of e through references
+func (lr *lrPlanarity) sign(e int) int {
+	if lr.ref[e] != -1 {
+		lr.side[e] *= lr.sign(lr.ref[e])
+		lr.ref[e] = -1
+	}
+	return lr.side[e]
+}
+
+// buildEmbedding orders outgoing edges by signed nesting depth and inserts incoming ones with third DFS
+func (lr *lrPlanarity) buildEmbedding() {
+	n := len(lr.adj)
+	for e := range lr.source {
+		lr.nesting[e] *= lr.sign(e)
+	}
+
+	lr.cw = make([]map[int]int, n)
+	lr.ccw = make([]map[int]int, n)
+	lr.first = make([]int, n)
+	lr.leftRef = make([]int, n)
+	lr.rightRef = make([]int, n)
+	for v := range n {
+		lr.cw[v] = make(map[int]int)
+		lr.ccw[v] = make(map[int]int)
+		lr.first[v] = -1
+
+		lr.sortByNesting(v)
+		previous := -1
+		for _, e := range lr.orderedAdj[v] {
+			lr.addHalfEdgeCW(v, lr.target[e], previous)
+			previous = lr.target[e]
+		}
+	}
+
+	for _, root := range lr.roots {
+		lr.embed(root)
+	}
+}
+
+// embed is the third DFS, it places incoming edges into rotations
+func (lr *lrPlanarity) embed(v int) {
+	for _, ei := range lr.orderedAdj[v] {
+		w := lr.target[ei]
+		if ei == lr.parentEdge[w] { // Tree edge
+			lr.addHalfEdgeCCW(w, v, lr.first[w]) // Parent becomes the first neighbor
+			lr.leftRef[v] = w
+			lr.rightRef[v] = w
+			lr.embed(w)
+		} else if lr.side[ei] == 1 { // Back edge on the right
+			lr.addHalfEdgeCW(w, v, lr.rightRef[w])
+		} else { // Back edge on the left
+			lr.addHalfEdgeCCW(w, v, lr.leftRef[w])
+			lr.leftRef[w] = v
+		}
+	}
+}
+
+// addHalfEdgeCW puts w into rotation of v right after ref clockwise, ref -1 for the first neighbor
+func (lr *lrPlanarity) addHalfEdgeCW(v, w, ref int) {
+	if ref == -1 {
+		lr.cw[v][w], lr.ccw[v][w] = w, w
+		lr.first[v] = w
+		return
+	}
+	next := lr.cw[v][ref]
+	lr.cw[v][ref], lr.ccw[v][w] = w, ref
+	lr.cw[v][w], lr.ccw[v][next] = next, w
+}
+
+// addHalfEdgeCCW puts w into rotation of v right before ref clockwise
+func (lr *lrPlanarity) addHalfEdgeCCW(v, w, ref int) {
+	if ref == -1 {
+		lr.addHalfEdgeCW(v, w, -1)
+		return
+	}
+	lr.addHalfEdgeCW(v, w, lr.ccw[v][ref])
+	if lr.first[v] == ref {
+		lr.first[v] = w
+	}
+}
+
+// rotation lists neighbors of v clockwise from the first one
+func (lr *lrPlanarity) rotation(v int) []int {
+	result := []int{}
+	if lr.first[v] == -1 {
+		return result
+	}
+	for w := lr.first[v]; ; {
+		result = append(result, w)
+		w = lr.cw[v][w]
+		if w == lr.first[v] {
+			return result
+		}
+	}
+}
+
+// TestPlanarity checks planarity, returning embedding or Kuratowski subdivision
+func TestPlanarity(gr *graph.Graph) (*PlanarityResult, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+	if gr.Options.IsDirected {
+		return nil, graph.ThrowGraphDirected()
+	}
+
+	keys := getSortedKeys(gr.Nodes)
+	index := make(map[graph.TKey]int, len(keys))
+	for i, key := range keys {
+		index[key] = i
+	}
+
+	// Simple graph, every pair keeps its smallest edge key
+	pairKey := make(map[[2]int]graph.TKey)
+	edges := [][2]int{}
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		u, v := index[edge.Source], index[edge.Destination]
+		if u == v {
+			continue
+		}
+		pair := [2]int{min(u, v), max(u, v)}
+		if _, ok := pairKey[pair]; !ok {
+			pairKey[pair] = key
+			edges = append(edges, pair)
+		}
+	}
+
+	result := &PlanarityResult{}
+	lr := newLRPlanarity(len(keys), edges)
+	if lr.run(true) {
+		result.IsPlanar = true
+		result.Embedding = make(map[graph.TKey][]graph.TKey, len(keys))
+		for v, key := range keys {
+			rotation := lr.rotation(v)
+			result.Embedding[key] = make([]graph.TKey, len(rotation))
+			for i, w := range rotation {
+				result.Embedding[key][i] = keys[w]
+			}
+		}
+		result.Message = "Graph is planar"
+		return result, nil
+	}
+
+	// Delete edges while graph stays non planar
+	witness := slices.Clone(edges)
+	for i := 0; i < len(witness); {
+		rest := slices.Delete(slices.Clone(witness), i, i+1)
+		if !newLRPlanarity(len(keys), rest).run(false) {
+			witness = rest
+		} else {
+			i++
+		}
+	}
+
+	degree := make(map[int]int)
+	for _, pair := range witness {
+		result.Kuratowski = append(result.Kuratowski, pairKey[pair])
+		degree[pair[0]]++
+		degree[pair[1]]++
+	}
+	slices.Sort(result.Kuratowski)
+
+	result.KuratowskiType = "K3,3"
+	for v, d := range degree {
+		if d >= 3 {
+			result.BranchNodes = append(result.BranchNodes, keys[v])
+		}
+		if d == 4 {
+			result.KuratowskiType = "K5"
+		}
+	}
+	slices.Sort(result.BranchNodes)
+
+	result.Message = fmt.Sprintf("Graph is not planar: contains subdivision of %s", result.KuratowskiType)
+	return result, nil
+}
+
+// FormatPlanarityResult creates a formatted string representation
+func (result *PlanarityResult) FormatPlanarityResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("PLANARITY TEST (Left-Right)\n\n")
+	sb.WriteString(fmt.Sprintf("Total vertices: %d\n", len(gr.Nodes)))
+	sb.WriteString(fmt.Sprintf("Total edges: %d\n", len(gr.Edges)))
+	sb.WriteString(fmt.Sprintf("Planar: %v\n\n", result.IsPlanar))
+
+	if result.IsPlanar {
+		sb.WriteString("EMBEDDING (neighbors clockwise)\n")
+		sb.WriteString(strings.Repeat("─", 50) + "\n")
+		for _, key := range getSortedMapKeys(result.Embedding) {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", formatNodeName(gr, key), formatKeyList(result.Embedding[key])))
+		}
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("KURATOWSKI SUBGRAPH (subdivision of %s)\n", result.KuratowskiType))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	sb.WriteString(fmt.Sprintf("Branch nodes: %s\n", formatKeyList(result.BranchNodes)))
+	sb.WriteString(fmt.Sprintf("Edges (%d):\n", len(result.Kuratowski)))
+	for _, key := range result.Kuratowski {
+		edge := gr.Edges[key]
+		sb.WriteString(fmt.Sprintf("  Edge %d: %s — %s\n", key, formatNodeName(gr, edge.Source), formatNodeName(gr, edge.Destination)))
+	}
+
+	return sb.String()
+}
@@ -18,33 +18,72 @@ type MSTResult struct {
 	TotalWeight graph.TWeight // Total weight of all edges in MST
 	Edges       []*graph.Edge // List of edges that form the MST
 	IsPossible  bool          // Whether MST construction is possible (graph must be connected)
+	Components  int           // Number of trees in the result, more than 1 only for forests
+	Algorithm   string        // Name of algorithm that built the tree
+	IsMaximum   bool          // Whether maximum spanning tree was built instead of minimum
+}
+
+/*
+ * Every MST algorithm accepts the same options via functional options pattern:
+ *
+ * FindMSTKruskal(gr, WithMSTMaximum(true), WithMSTForest(true))
+ *
+ * builds maximum spanning forest, for example.
+ */
+
+// MSTOptions configures spanning tree algorithms
+type MSTOptions struct {
+	Maximum bool // Build maximum spanning tree instead of minimum
+	Forest  bool // Build spanning forest for disconnected graphs instead of failing
+}
+
+func WithMSTMaximum(maximum bool) graph.Option[MSTOptions] {
+	return func(opts *MSTOptions) {
+		opts.Maximum = maximum
+	}
+}
+
+func WithMSTForest(forest bool) graph.Option[MSTOptions] {
+	return func(opts *MSTOptions) {
+		opts.Forest = forest
+	}
 }
 
 // FindMSTPrim finds Minimum Spanning Tree using Prim's algorithm
 // Time Complexity: O(V^2) for this implementation, can be optimized to O(E log V) with priority queue
 // Space Complexity: O(V + E)
-func FindMSTPrim(gr *graph.Graph) (*MSTResult, error) {
+func FindMSTPrim(gr *graph.Graph, options ...graph.Option[MSTOptions]) (*MSTResult, error) {
 	// Input validation: check if graph nodes exist
 	if gr.Nodes == nil {
 		return nil, graph.ThrowNodesListIsNil()
 	}
 
+	opts := MSTOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
 	// Base case: empty graph is trivially a tree
 	if len(gr.Nodes) == 0 {
 		return &MSTResult{
 			TotalWeight: 0,
 			Edges:       []*graph.Edge{},
 			IsPossible:  true,
+			Algorithm:   "Prim",
+			IsMaximum:   opts.Maximum,
 		}, nil
 	}
 
 	// MST Requirement: graph must be connected
 	// A disconnected graph cannot have a spanning tree that connects all vertices
-	if !gr.IsConnected() {
+	if !opts.Forest && !gr.IsConnected() {
 		return &MSTResult{
 			TotalWeight: 0,
 			Edges:       []*graph.Edge{},
 			IsPossible:  false, // MST not possible for disconnected graphs
+			Components:  gr.GetConnectedComponents(),
+			Algorithm:   "Prim",
+			IsMaximum:   opts.Maximum,
 		}, nil
 	}
 
@@ -53,23 +92,25 @@ func FindMSTPrim(gr *graph.Graph) (*MSTResult, error) {
 	if gr.Options.IsDirected {
 		tempGraph := gr.Copy()
 		tempGraph.UpdateGraph(graph.WithGraphDirected(false))
-		return findMSTPrimInternal(tempGraph)
+		return findMSTPrimInternal(tempGraph, opts)
 	}
 
 	// For undirected graphs, proceed with normal MST calculation
-	return findMSTPrimInternal(gr)
+	return findMSTPrimInternal(gr, opts)
 }
 
 // findMSTPrimInternal implements the core Prim's algorithm logic
 // Algorithm Strategy: Grow the MST by repeatedly adding the cheapest edge
 // that connects a vertex in MST to a vertex outside MST
-func findMSTPrimInternal(gr *graph.Graph) (*MSTResult, error) {
+func findMSTPrimInternal(gr *graph.Graph, opts MSTOptions) (*MSTResult, error) {
 	// Handle empty graph case
 	if len(gr.Nodes) == 0 {
 		return &MSTResult{
 			TotalWeight: 0,
 			Edges:       []*graph.Edge{},
 			IsPossible:  true,
+			Algorithm:   "Prim",
+			IsMaximum:   opts.Maximum,
 		}, nil
 	}
 
@@ -80,58 +121,66 @@ func findMSTPrimInternal(gr *graph.Graph) (*MSTResult, error) {
 	var mstEdges []*graph.Edge
 
 	// Step 1: Initialize with any vertex
-	// Prim's algorithm can start from any vertex - choice doesn't affect result
-	var firstVertex graph.TKey
-	for vertex := range gr.Nodes {
-		firstVertex = vertex
-		break // Pick the first available vertex
-		// I do love working with queues in go
-	}
-	inMST[firstVertex] = true // Mark first vertex as included
-
-	// Step 2: Repeat until all vertices are in MST
-	// MST must contain exactly V-1 edges for V vertices
-	for len(inMST) < len(gr.Nodes) {
-		var bestEdge *graph.Edge             // The edge with minimum weight
-		bestWeight := graph.TWeight(1 << 30) // Initialize with "infinity"
-
-		// Step 2.1: Find minimum weight edge connecting MST to non-MST vertices
-		// Strategy: Check all edges from vertices inside MST to their neighbors outside MST
-		for u := range inMST {
-			// Explore all neighbors of vertex u (which is already in MST)
-			for _, neighbor := range gr.AdjacencyMap[u] {
-				// Only consider neighbors that are NOT yet in MST
-				if !inMST[neighbor] {
-					// Find the actual edge between u and neighbor
-					edge := getEdgeBetweenReliable(gr, u, neighbor)
-
-					// If edge exists and has lower weight than current best, update best edge
-					if edge != nil && edge.Weight < bestWeight {
-						bestWeight = edge.Weight
-						bestEdge = edge
+	// Prim's algorithm can start from any vertex - choice doesn't affect result.
+	// Spanning forest just restarts the growth from next vertex outside MST
+	components := 0
+	for _, firstVertex := range getSortedKeys(gr.Nodes) {
+		if inMST[firstVertex] {
+			continue
+		}
+		components++
+		inMST[firstVertex] = true // Mark first vertex as included
+
+		// Step 2: Repeat until all vertices are in MST
+		// MST must contain exactly V-1 edges for V vertices
+		for len(inMST) < len(gr.Nodes) {
+			var bestEdge *graph.Edge // The edge with minimum (or maximum) weight
+
+			// Step 2.1: Find best edge connecting MST to non-MST vertices
+			// Strategy: Check all edges from vertices inside MST to their neighbors outside MST
+			for u := range inMST {
+				// Explore all neighbors of vertex u (which is already in MST)
+				for _, neighbor := range gr.AdjacencyMap[u] {
+					// Only consider neighbors that are NOT yet in MST
+					if !inMST[neighbor] {
+						// Find the actual edge between u and neighbor
+						edge := getBestEdgeBetween(gr, u, neighbor, opts.Maximum)
+
+						// If edge exists and is better than current best, update best edge
+						if edge != nil && (bestEdge == nil || isBetterMSTEdge(edge, bestEdge, opts.Maximum)) {
+							bestEdge = edge
+						}
 					}
 				}
 			}
+
+			// Current tree cannot grow anymore
+			if bestEdge == nil {
+				break
+			}
+
+			mstEdges = append(mstEdges, bestEdge)
+
+			if inMST[bestEdge.Source] {
+				inMST[bestEdge.Destination] = true
+			} else {
+				inMST[bestEdge.Source] = true
+			}
+
+			// At this point, MST has grown by one vertex and one edge
+			// The algorithm maintains the invariant that MST is always a tree
 		}
 
-		if bestEdge == nil {
+		if components > 1 && !opts.Forest {
 			return &MSTResult{
 				TotalWeight: 0,
 				Edges:       []*graph.Edge{},
 				IsPossible:  false,
+				Components:  gr.GetConnectedComponents(),
+				Algorithm:   "Prim",
+				IsMaximum:   opts.Maximum,
 			}, nil
 		}
-
-		mstEdges = append(mstEdges, bestEdge)
-
-		if inMST[bestEdge.Source] {
-			inMST[bestEdge.Destination] = true
-		} else {
-			inMST[bestEdge.Source] = true
-		}
-
-		// At this point, MST has grown by one vertex and one edge
-		// The algorithm maintains the invariant that MST is always a tree
 	}
 
 	// Step 3: Calculate total weight of MST
@@ -144,29 +193,28 @@ func findMSTPrimInternal(gr *graph.Graph) (*MSTResult, error) {
 		TotalWeight: totalWeight,
 		Edges:       mstEdges,
 		IsPossible:  true,
+		Components:  components,
+		Algorithm:   "Prim",
+		IsMaximum:   opts.Maximum,
 	}, nil
 }
 
-// getEdgeBetweenReliable finds an edge between two vertices in the graph
-// Handles both directed and undirected graphs correctly
-func getEdgeBetweenReliable(gr *graph.Graph, u, v graph.TKey) *graph.Edge {
-	// First, search for edge u → v (forward direction)
-	for _, edge := range gr.Edges {
-		if edge.Source == u && edge.Destination == v {
-			return edge
-		}
-	}
+// getBestEdgeBetween finds the best edge between two vertices in the graph
+// Handles both directed and undirected graphs correctly. In multigraphs all
+// parallel edges are compared, so the cheapest (or the heaviest) one is taken
+func getBestEdgeBetween(gr *graph.Graph, u, v graph.TKey, maximum bool) *graph.Edge {
+	var best *graph.Edge
 
-	// For undirected graphs, also search for edge v → u (reverse direction)
-	// In undirected graphs, edge A-B is the same as B-A
-	if !gr.Options.IsDirected {
-		for _, edge := range gr.Edges {
-			if edge.Source == v && edge.Destination == u {
-				return edge
-			}
+	for _, edge := range gr.Edges {
+		// Edge u → v (forward direction), or v → u for undirected graphs
+		// In undirected graphs, edge A-B is the same as B-A
+		forward := edge.Source == u && edge.Destination == v
+		backward := !gr.Options.IsDirected && edge.Source == v && edge.Destination == u
+		if (forward || backward) && (best == nil || isBetterMSTEdge(edge, best, maximum)) {
+			best = edge
 		}
 	}
 
-	// No edge found between u and v
-	return nil
+	// nil if no edge found between u and v
+	return best
 }
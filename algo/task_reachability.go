@@ -0,0 +1,366 @@
+/*
+ * This package contains algorithms and tasks for my SSU course
+ */
+
+package algo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+/*
+ * Task: Answer "can u reach v" queries, find transitive closure and reduction
+ *
+ * Reachability Index:
+ *   1. Tarjan's algorithm finds strongly connected components. All nodes of a
+ *      component reach the same nodes, so the graph shrinks to condensation,
+ *      which is a DAG. Tarjan emits components sinks first, so every arc of
+ *      condensation goes from bigger component number to smaller one
+ *   2. Going through components in that order, reach(c) = {c} ∪ reach of all
+ *      successors, as a bitset over components (whole words are or'ed)
+ *   3. CanReach(u, v) is one bit lookup: v's component in reach of u's
+ *      component. Like other indexes it refuses to answer once graph is
+ *      changed (see graph.Revision)
+ * O(V + E) for components plus O(C · E' / 64) for bitsets, where C and E' are
+ * sizes of condensation. Memory is C² bits, so dependency graphs with
+ * thousands of nodes fit easily. Undirected edges work both ways.
+ *
+ * Transitive Closure - directed graph with arc u → v whenever v is reachable
+ * from u by a nonempty path. Loops u → u appear only for nodes on cycles.
+ *
+ * Transitive Reduction - for DAG the smallest graph with the same
+ * reachability, and it is unique: arc u → v stays only if there is no other
+ * path from u to v. Successors of u are taken in topological order; arc to a
+ * successor already reached through earlier ones is redundant, otherwise its
+ * reach set joins the covered set. Parallel arcs are redundant too, the one
+ * with smallest key stays.
+ */
+
+// ReachabilityIndex answers reachability queries between nodes of one graph
+type ReachabilityIndex struct {
+	graph     *graph.Graph
+	revision  uint64
+	keys      []graph.TKey
+	index     map[graph.TKey]int
+	component []int    // Strongly connected component of every node
+	members   [][]int  // Nodes of every component
+	cyclic    []bool   // Component has a cycle: several nodes or a loop
+	reach     []bitset // Components reachable from every component, itself included
+	Message   string   // Status message
+}
+
+// TransitiveResult represents transitive closure or reduction of graph
+type TransitiveResult struct {
+	Kind    string       // Closure or reduction
+	Graph   *graph.Graph // Resulting graph on the same nodes
+	Added   int          // Arcs of closure that are not edges of original graph
+	Removed []graph.TKey // Edges dropped by reduction
+	Message string       // Status message
+}
+
+// BuildReachabilityIndex condenses graph into components and precomputes their reachability
+func BuildReachabilityIndex(gr *graph.Graph) (*ReachabilityIndex, error) {
+	if gr.Nodes == nil {
+		return nil, graph.ThrowNodesListIsNil()
+	}
+
+	ri := &ReachabilityIndex{graph: gr, revision: gr.Revision(), keys: getSortedKeys(gr.Nodes)}
+	ri.index = make(map[graph.TKey]int, len(ri.keys))
+	for i, key := range ri.keys {
+		ri.index[key] = i
+	}
+	n := len(ri.keys)
+	adjacency := make([][]int, n)
+	loops := make([]bool, n)
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		edge := gr.Edges[key]
+		u, v := ri.index[edge.Source], ri.index[edge.Destination]
+		if u == v {
+			loops[u] = true
+			continue
+		}
+		adjacency[u] = append(adjacency[u], v)
+		if !gr.Options.IsDirected {
+			adjacency[v] = append(adjacency[v], u)
+		}
+	}
+
+	// Tarjan's algorithm, components get numbers in order they are closed
+	ri.component = make([]int, n)
+	order := make([]int, n) // DFS number, 0 for unvisited
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	stack := []int{}
+	counter := 0
+	var strongConnect func(v int)
+	strongConnect = func(v int) {
+		counter++
+		order[v], low[v] = counter, counter
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range adjacency[v] {
+			if order[w] == 0 {
+				strongConnect(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], order[w])
+			}
+		}
+		if low[v] != order[v] {
+			return
+		}
+		c := len(ri.members)
+		members := []int{}
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			ri.component[w] = c
+			members = append(members, w)
+			if w == v {
+				break
+			}
+		}
+		slices.Sort(members)
+		ri.members = append(ri.members, members)
+	}
+	for v := range n {
+		if order[v] == 0 {
+			strongConnect(v)
+		}
+	}
+
+	count := len(ri.members)
+	ri.cyclic = make([]bool, count)
+	successors := make([][]int, count) // Arcs of condensation
+	ri.reach = make([]bitset, count)
+	for c, members := range ri.members {
+		ri.cyclic[c] = len(members) > 1
+		seen := make(map[int]bool)
+		for _, v := range members {
+			ri.cyclic[c] = ri.cyclic[c] || loops[v]
+			for _, w := range adjacency[v] {
+				if d := ri.component[w]; d != c && !seen[d] {
+					seen[d] = true
+					successors[c] = append(successors[c], d)
+				}
+			}
+		}
+
+		// Successors are closed earlier, so their reach is ready
+		ri.reach[c] = makeBitset(count)
+		ri.reach[c].add(c)
+		for _, d := range successors[c] {
+			ri.reach[c].or(ri.reach[d])
+		}
+	}
+
+	ri.Message = fmt.Sprintf("Reachability index built: %d nodes in %d strongly connected components", n, count)
+	return ri, nil
+}
+
+// Graph returns graph the index is bound to
+func (ri *ReachabilityIndex) Graph() *graph.Graph {
+	return ri.graph
+}
+
+// Valid checks that graph was not changed since index was built
+func (ri *ReachabilityIndex) Valid() bool {
+	return ri.graph.Revision() == ri.revision
+}
+
+// Components returns number of strongly connected components
+func (ri *ReachabilityIndex) Components() int {
+	return len(ri.members)
+}
+
+// CanReach checks if there is a path from u to v, every node reaches itself
+func (ri *ReachabilityIndex) CanReach(u, v graph.TKey) (bool, error) {
+	if err := ri.check(u, v); err != nil {
+		return false, err
+	}
+	return ri.canReach(u, v), nil
+}
+
+// Descendants lists nodes reachable from u by a nonempty path
+func (ri *ReachabilityIndex) Descendants(u graph.TKey) ([]graph.TKey, error) {
+	if err := ri.check(u); err != nil {
+		return nil, err
+	}
+	return ri.descendants(u), nil
+}
+
+// check refuses queries on outdated index and unknown nodes
+func (ri *ReachabilityIndex) check(keys ...graph.TKey) error {
+	if !ri.Valid() {
+		return fmt.Errorf("reachability index is outdated: graph was changed after it was built")
+	}
+	for _, key := range keys {
+		if _, ok := ri.index[key]; !ok {
+			return graph.ThrowNodeWithKeyNotExists(key)
+		}
+	}
+	return nil
+}
+
+// canReach looks up one bit, nodes must be in the index
+func (ri *ReachabilityIndex) canReach(u, v graph.TKey) bool {
+	return ri.reach[ri.component[ri.index[u]]].has(ri.component[ri.index[v]])
+}
+
+// descendants collects members of reachable components, node must be in the index
+func (ri *ReachabilityIndex) descendants(u graph.TKey) []graph.TKey {
+	from := ri.index[u]
+	c := ri.component[from]
+	result := []graph.TKey{}
+	for _, d := range ri.reach[c].elements() {
+		for _, v := range ri.members[d] {
+			if v != from || ri.cyclic[c] {
+				result = append(result, ri.keys[v])
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// TransitiveClosure builds directed graph with arc u → v for every v reachable from u
+func TransitiveClosure(gr *graph.Graph) (*TransitiveResult, error) {
+	if !gr.Options.IsDirected {
+		return nil, graph.ThrowGraphNotDirected()
+	}
+	ri, err := BuildReachabilityIndex(gr)
+	if err != nil {
+		return nil, err
+	}
+
+	closure := graph.MakeGraph(graph.WithGraphDirected(true))
+	for _, key := range ri.keys {
+		if err := closure.AddNode(graph.MakeNode(key, graph.WithNodeLabel(gr.Nodes[key].Label))); err != nil {
+			return nil, err
+		}
+	}
+	existing := make(map[[2]graph.TKey]bool)
+	for _, edge := range gr.Edges {
+		existing[[2]graph.TKey{edge.Source, edge.Destination}] = true
+	}
+
+	result := &TransitiveResult{Kind: "Closure", Graph: closure, Removed: []graph.TKey{}}
+	edgeKey := graph.TKey(1)
+	for _, u := range ri.keys {
+		for _, v := range ri.descendants(u) {
+			closure.Edges[edgeKey] = graph.MakeEdge(edgeKey, u, v)
+			edgeKey++
+			if !existing[[2]graph.TKey{u, v}] {
+				result.Added++
+			}
+		}
+	}
+	// Adjacency is rebuilt once, AddEdge would rebuild it for every arc
+	closure.RebuildAdjacencyMap()
+
+	result.Message = fmt.Sprintf("Transitive closure has %d arcs, %d of them new", len(closure.Edges), result.Added)
+	return result, nil
+}
+
+// TransitiveReduction removes edges of DAG implied by other paths, keeping keys and attributes of the rest
+func TransitiveReduction(gr *graph.Graph) (*TransitiveResult, error) {
+	if !gr.Options.IsDirected {
+		return nil, graph.ThrowGraphNotDirected()
+	}
+	ri, err := BuildReachabilityIndex(gr)
+	if err != nil {
+		return nil, err
+	}
+	for c, cyclic := range ri.cyclic {
+		if cyclic {
+			return nil, fmt.Errorf("transitive reduction needs acyclic graph, node %d is on a cycle", ri.keys[ri.members[c][0]])
+		}
+	}
+
+	// In a DAG components are single nodes, and bigger component number comes earlier in topological order
+	arcs := make([][]graph.TKey, len(ri.keys)) // Edge keys by source node
+	for _, key := range getSortedMapKeys(gr.Edges) {
+		u := ri.index[gr.Edges[key].Source]
+		arcs[u] = append(arcs[u], key)
+	}
+
+	removed := []graph.TKey{}
+	for u := range ri.keys {
+		slices.SortStableFunc(arcs[u], func(a, b graph.TKey) int {
+			return ri.component[ri.index[gr.Edges[b].Destination]] - ri.component[ri.index[gr.Edges[a].Destination]]
+		})
+		covered := makeBitset(len(ri.members))
+		for _, key := range arcs[u] {
+			c := ri.component[ri.index[gr.Edges[key].Destination]]
+			if covered.has(c) {
+				removed = append(removed, key)
+				continue
+			}
+			covered.or(ri.reach[c])
+		}
+	}
+	slices.Sort(removed)
+
+	reduction := gr.Copy()
+	for _, key := range removed {
+		delete(reduction.Edges, key)
+	}
+	reduction.RebuildAdjacencyMap()
+
+	return &TransitiveResult{
+		Kind:    "Reduction",
+		Graph:   reduction,
+		Removed: removed,
+		Message: fmt.Sprintf("Transitive reduction keeps %d of %d edges", len(reduction.Edges), len(gr.Edges)),
+	}, nil
+}
+
+// FormatReachabilityIndex creates a formatted string representation
+func (ri *ReachabilityIndex) FormatReachabilityIndex(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString("REACHABILITY INDEX (Tarjan SCC + bitsets)\n\n")
+	sb.WriteString(fmt.Sprintf("Nodes: %d\n", len(ri.keys)))
+	sb.WriteString(fmt.Sprintf("Strongly connected components: %d\n", len(ri.members)))
+	sb.WriteString(fmt.Sprintf("Index size: %d bytes\n\n", len(ri.members)*len(makeBitset(len(ri.members)))*8))
+
+	sb.WriteString(fmt.Sprintf("%-16s %-10s %-8s %s\n", "Node", "Component", "Reaches", "Descendants"))
+	sb.WriteString(strings.Repeat("─", 70) + "\n")
+	for _, key := range ri.keys {
+		descendants := ri.descendants(key)
+		sb.WriteString(fmt.Sprintf("%-16s %-10d %-8d %s\n",
+			formatNodeName(gr, key), ri.component[ri.index[key]], len(descendants), formatKeyList(descendants)))
+	}
+
+	return sb.String()
+}
+
+// FormatTransitiveResult creates a formatted string representation
+func (result *TransitiveResult) FormatTransitiveResult(gr *graph.Graph) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("TRANSITIVE %s\n\n", strings.ToUpper(result.Kind)))
+	sb.WriteString(fmt.Sprintf("Original edges: %d\n", len(gr.Edges)))
+	sb.WriteString(fmt.Sprintf("Resulting edges: %d\n", len(result.Graph.Edges)))
+	if result.Kind == "Closure" {
+		sb.WriteString(fmt.Sprintf("New arcs: %d\n", result.Added))
+	} else {
+		sb.WriteString(fmt.Sprintf("Removed edges: %s\n", formatKeyList(result.Removed)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(fmt.Sprintf("%-16s %s\n", "Node", "Successors"))
+	sb.WriteString(strings.Repeat("─", 50) + "\n")
+	for _, key := range getSortedKeys(result.Graph.Nodes) {
+		successors := slices.Clone(result.Graph.AdjacencyMap[key])
+		slices.Sort(successors)
+		sb.WriteString(fmt.Sprintf("%-16s %s\n", formatNodeName(gr, key), formatKeyList(successors)))
+	}
+
+	return sb.String()
+}
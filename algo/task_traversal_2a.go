@@ -4,10 +4,19 @@
 
 package algo
 
-import "github.com/tolstovrob/graph-go/graph"
+import (
+	"sort"
+
+	"github.com/tolstovrob/graph-go/graph"
+)
 
 /*
  * Task: Check if there exists a vertex that can be removed to make the graph a tree
+ *
+ * For undirected graphs removing vertex v leaves a tree iff the rest is connected
+ * and has exactly |V| - 2 edges. Connectivity of the rest is answered by
+ * articulation points, so the whole check is O(V + E) instead of copying
+ * the graph once per vertex.
  */
 
 func CanRemoveVertexToMakeTree(gr *graph.Graph) (bool, []graph.TKey, error) {
@@ -15,6 +24,60 @@ func CanRemoveVertexToMakeTree(gr *graph.Graph) (bool, []graph.TKey, error) {
 		return false, nil, graph.ThrowNodesListIsNil()
 	}
 
+	// IsTree on directed graphs follows edge directions, so keep the exact check there
+	if gr.Options.IsDirected {
+		return canRemoveVertexBruteForce(gr)
+	}
+
+	analysis, err := FindBiconnectivity(gr)
+	if err != nil {
+		return false, nil, err
+	}
+
+	isArticulate := make(map[graph.TKey]bool)
+	for _, vertex := range analysis.ArticulationPoints {
+		isArticulate[vertex] = true
+	}
+
+	// Count edges incident to each vertex by key, so loops and parallel edges count once
+	incident := make(map[graph.TKey]int)
+	for _, edge := range gr.Edges {
+		incident[edge.Source]++
+		if edge.Source != edge.Destination {
+			incident[edge.Destination]++
+		}
+	}
+
+	incidence := buildUndirectedIncidence(gr)
+	components := gr.GetConnectedComponents()
+	nodesLeft := len(gr.Nodes) - 1
+
+	var candidates []graph.TKey
+	for key := range gr.Nodes {
+		// Tree on remaining vertices must have exactly nodesLeft - 1 edges
+		if nodesLeft > 0 && len(gr.Edges)-incident[key] != nodesLeft-1 {
+			continue
+		}
+
+		// The rest must stay connected: either v is inside the only component and
+		// is not a cut vertex, or v is isolated and there is exactly one more component
+		isolated := !hasProperNeighbor(incidence[key], key)
+		connected := nodesLeft == 0 ||
+			(components == 1 && !isArticulate[key]) ||
+			(components == 2 && isolated)
+
+		if connected {
+			candidates = append(candidates, key)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+	return len(candidates) > 0, candidates, nil
+}
+
+// canRemoveVertexBruteForce removes every vertex from a copy and checks the rest
+// Time Complexity: O(V * (V + E))
+func canRemoveVertexBruteForce(gr *graph.Graph) (bool, []graph.TKey, error) {
 	var candidates []graph.TKey
 
 	// For each vertex, check if removing it makes the graph a tree
@@ -31,5 +94,6 @@ func CanRemoveVertexToMakeTree(gr *graph.Graph) (bool, []graph.TKey, error) {
 		}
 	}
 
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
 	return len(candidates) > 0, candidates, nil
 }
@@ -9,7 +9,10 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rivo/tview"
 	"github.com/tolstovrob/graph-go/algo"
@@ -23,11 +26,33 @@ func (cli *CLIService) showAlgorithmsMenu() {
 		AddItem("Remove pendant", "Remove all pendant nodes. Destructive action", '3', cli.showRemovePendantVertices).
 		AddItem("Vertex to Tree", "Check if removing a vertex makes graph a tree", '4', cli.showVertexToTreeCheck).
 		AddItem("Connected Components", "Count and analyze connected components", '5', cli.showConnectedComponentsAnalysis).
-		AddItem("Minimum Spanning Tree", "Find MST using Prim's algorithm", '6', cli.showMSTPrim).
+		AddItem("Minimum Spanning Tree", "Find MST using Prim's, Kruskal's or Borůvka's algorithm", '6', cli.showMSTForm).
 		AddItem("All Pairs Shortest Path", "Find shortest paths between all vertices", '7', cli.showAllPairsShortestPath).
 		AddItem("Eccentricity and Radius", "Find eccentricity of vertices and graph radius", '8', cli.showEccentricityAndRadius).
 		AddItem("Negative Cycles", "Find all negative cycles using Bellman-Ford", '9', cli.showNegativeCycles).
 		AddItem("Maximum Flow", "Find maximum flow from source to sink", '0', cli.showMaxFlowForm).
+		AddItem("Bridges and Articulation Points", "Find bridges, cut vertices and biconnected components", 'a', cli.showBiconnectivity).
+		AddItem("Eulerian Trail", "Find Eulerian path or circuit using Hierholzer's algorithm", 'b', cli.showEulerianTrail).
+		AddItem("Chinese Postman", "Find shortest closed route using every edge", 'c', cli.showChinesePostman).
+		AddItem("Minimum Arborescence", "Find minimum spanning arborescence of directed graph", 'd', cli.showArborescenceForm).
+		AddItem("Shortest Paths from Source", "Bellman-Ford or SPFA with negative weights, or Dijkstra", 'e', cli.showBellmanFordForm).
+		AddItem("Minimum-Cost Flow", "Find cheapest flow using edge capacities and costs", 'f', cli.showMinCostFlowForm).
+		AddItem("Global Minimum Cut", "Find cheapest cut of undirected graph using Stoer-Wagner", 'g', cli.showGlobalMinCut).
+		AddItem("Gomory-Hu Tree", "Build tree of minimum cuts between all pairs of nodes", 'h', cli.showGomoryHuTree).
+		AddItem("Connectivity and Disjoint Paths", "Edge/vertex connectivity and Menger's disjoint paths", 'i', cli.showConnectivityForm).
+		AddItem("Bipartite Matching", "Check bipartiteness, find Hopcroft-Karp matching and König cover", 'j', cli.showBipartiteMatching).
+		AddItem("Weighted Matching", "Hungarian assignment or Edmonds' blossom maximum matching", 'k', cli.showWeightedMatchingForm).
+		AddItem("Graph Coloring", "Greedy, DSatur or exact vertex coloring and edge coloring", 'l', cli.showColoringForm).
+		AddItem("Cliques and Vertex Covers", "Maximal cliques, maximum independent set and minimum vertex cover", 'm', cli.showCliquesAndCovers).
+		AddItem("Centrality", "Rank nodes by betweenness, closeness, PageRank, eigenvector or HITS", 'n', cli.showCentralityForm).
+		AddItem("Community Detection", "Find communities by Louvain or label propagation", 'o', cli.showCommunitiesForm).
+		AddItem("K-Core Decomposition", "Find core numbers and replace graph with its k-core", 'p', cli.showKCoreForm).
+		AddItem("Isomorphism and Subgraph Matching", "Match loaded pattern graph against current graph (VF2)", 'r', cli.showIsomorphismForm).
+		AddItem("Planarity Test", "Find planar embedding or Kuratowski subgraph (left-right test)", 's', cli.showPlanarity).
+		AddItem("Dominator Tree", "Find immediate dominators and dominance frontiers (Lengauer-Tarjan)", 't', cli.showDominatorsForm).
+		AddItem("K Shortest Paths", "Yen's k loopless paths, or all shortest paths as a DAG", 'u', cli.showKShortestPathsForm).
+		AddItem("Fast Point-to-Point Queries", "Bidirectional Dijkstra or contraction hierarchy index", 'v', cli.showPointToPointForm).
+		AddItem("Reachability and Transitive Closure", "Reachability queries, transitive closure and reduction of DAG", 'w', cli.showReachabilityForm).
 		AddItem("Back to Main Menu", "Return to main menu", 'q', func() {
 			cli.pages.SwitchToPage("main")
 		})
@@ -191,7 +216,8 @@ func (cli *CLIService) executeRemovePendantVertices() {
 }
 
 func (cli *CLIService) showVertexToTreeCheck() {
-	if len(cli.graph.Nodes) > 20 {
+	// Only directed graphs fall back to the slow per-vertex check
+	if cli.graph.Options.IsDirected && len(cli.graph.Nodes) > 20 {
 		modal := tview.NewModal().
 			SetText(fmt.Sprintf("Graph has %d vertices. This operation may take some time. Continue?", len(cli.graph.Nodes))).
 			AddButtons([]string{"Continue", "Cancel"}).
@@ -285,64 +311,90 @@ func countIsolatedVertices(sizes []int) int {
 	return count
 }
 
-func (cli *CLIService) showMSTPrim() {
-	cli.updateStatus("Finding Minimum Spanning Tree using Prim's algorithm...", Default)
+func (cli *CLIService) showMSTForm() {
+	form := tview.NewForm()
+	algorithms := []string{"Prim", "Kruskal", "Borůvka"}
+	algorithm := algorithms[0]
+	var maximum, forest bool
+
+	form.AddDropDown("Algorithm", algorithms, 0, func(option string, index int) {
+		algorithm = option
+	})
+	form.AddCheckbox("Maximum spanning tree", false, func(checked bool) {
+		maximum = checked
+	})
+	form.AddCheckbox("Spanning forest", false, func(checked bool) {
+		forest = checked
+	})
+	form.AddButton("Find Tree", func() {
+		cli.executeMST(algorithm, algo.WithMSTMaximum(maximum), algo.WithMSTForest(forest))
+	})
+	form.AddButton("Compare All", func() {
+		cli.executeMSTComparison(algo.WithMSTMaximum(maximum), algo.WithMSTForest(forest))
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Spanning Tree ")
+	cli.pages.AddAndSwitchToPage("mst_form", form, true)
+}
+
+func (cli *CLIService) executeMST(algorithm string, options ...graph.Option[algo.MSTOptions]) {
+	cli.updateStatus(fmt.Sprintf("Finding spanning tree using %s's algorithm...", algorithm), Default)
 
 	go func() {
-		result, err := algo.FindMSTPrim(cli.graph)
+		var result *algo.MSTResult
+		var err error
+		switch algorithm {
+		case "Kruskal":
+			result, err = algo.FindMSTKruskal(cli.graph, options...)
+		case "Borůvka":
+			result, err = algo.FindMSTBoruvka(cli.graph, options...)
+		default:
+			result, err = algo.FindMSTPrim(cli.graph, options...)
+		}
 
 		cli.app.QueueUpdateDraw(func() {
 			var resultText string
 			if err != nil {
 				resultText = fmt.Sprintf("Error: %v", err)
 				cli.updateStatus("MST calculation failed", Error)
-			} else if !result.IsPossible {
-				resultText = "MINIMUM SPANNING TREE ANALYSIS\n\n"
-				resultText += "MST is NOT possible for this graph\n\n"
-				resultText += "Reason: Graph is not connected\n"
-				resultText += "Prim's algorithm requires the graph to be connected to find a spanning tree."
-				cli.updateStatus("Graph is not connected - MST not possible", Error)
 			} else {
-				resultText = fmt.Sprintf("MINIMUM SPANNING TREE (Prim's Algorithm)\n\n")
-				resultText += fmt.Sprintf("Total weight: %d\n", result.TotalWeight)
-				resultText += fmt.Sprintf("Number of edges in MST: %d\n", len(result.Edges))
-				resultText += fmt.Sprintf("Theoretical minimum edges: %d\n\n", len(cli.graph.Nodes)-1)
-
-				resultText += fmt.Sprintf("MST EDGES:\n")
-				resultText += fmt.Sprintf("%-8s %-8s %-8s %-12s %s\n", "Key", "From", "To", "Weight", "Label")
-				resultText += fmt.Sprintf("%-8s %-8s %-8s %-12s %s\n", "────", "────", "──", "──────", "─────")
-
-				for _, edge := range result.Edges {
-					srcNode, _ := cli.graph.GetNodeByKey(edge.Source)
-					dstNode, _ := cli.graph.GetNodeByKey(edge.Destination)
-
-					srcLabel := fmt.Sprintf("%d", edge.Source)
-					if srcNode != nil && srcNode.Label != "" {
-						srcLabel = fmt.Sprintf("%d(%s)", edge.Source, srcNode.Label)
-					}
+				resultText = result.FormatMSTResult(cli.graph)
+				if result.IsPossible {
+					cli.updateStatus(fmt.Sprintf("Spanning tree found with total weight %d", result.TotalWeight), Success)
+				} else {
+					cli.updateStatus("Graph is not connected - MST not possible", Error)
+				}
+			}
 
-					dstLabel := fmt.Sprintf("%d", edge.Destination)
-					if dstNode != nil && dstNode.Label != "" {
-						dstLabel = fmt.Sprintf("%d(%s)", edge.Destination, dstNode.Label)
-					}
+			cli.showScrollableModal("Minimum Spanning Tree", resultText, "algorithms_menu")
+		})
+	}()
+}
 
-					resultText += fmt.Sprintf("%-8d %-8s %-8s %-12d %s\n",
-						edge.Key, srcLabel, dstLabel, edge.Weight, edge.Label)
-				}
+func (cli *CLIService) executeMSTComparison(options ...graph.Option[algo.MSTOptions]) {
+	cli.updateStatus("Comparing spanning tree algorithms...", Default)
 
-				resultText += fmt.Sprintf("\nGRAPH INFORMATION:\n")
-				resultText += fmt.Sprintf("Original graph: %d nodes, %d edges\n", len(cli.graph.Nodes), len(cli.graph.Edges))
-				resultText += fmt.Sprintf("MST covers: %d nodes, %d edges\n", len(cli.graph.Nodes), len(result.Edges))
+	go func() {
+		comparison, err := algo.CompareMSTAlgorithms(cli.graph, options...)
 
-				if len(result.Edges) != len(cli.graph.Nodes)-1 {
-					resultText += fmt.Sprintf("\nWarning: MST has %d edges but expected %d for %d nodes\n",
-						len(result.Edges), len(cli.graph.Nodes)-1, len(cli.graph.Nodes))
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			if err != nil {
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("MST comparison failed", Error)
+			} else {
+				resultText = comparison.FormatMSTComparison()
+				if comparison.Agree {
+					cli.updateStatus(comparison.Message, Success)
+				} else {
+					cli.updateStatus(comparison.Message, Error)
 				}
-
-				cli.updateStatus(fmt.Sprintf("MST found with total weight %d", result.TotalWeight), Success)
 			}
 
-			cli.showScrollableModal("Minimum Spanning Tree", resultText, "algorithms_menu")
+			cli.showScrollableModal("MST Cross-Check", resultText, "algorithms_menu")
 		})
 	}()
 }
@@ -363,7 +415,7 @@ func (cli *CLIService) showAllPairsShortestPath() {
 				cli.updateStatus("Invalid graph for shortest paths", Error)
 			} else {
 				resultText = result.FormatDistanceMatrix(cli.graph)
-				cli.updateStatus("All-pairs shortest paths computed successfully", Success)
+				cli.updateStatus(fmt.Sprintf("All-pairs shortest paths computed successfully (%s)", result.Algorithm), Success)
 			}
 
 			cli.showScrollableModal("All Pairs Shortest Path", resultText, "algorithms_menu")
@@ -393,10 +445,28 @@ func (cli *CLIService) showEccentricityAndRadius() {
 }
 
 func (cli *CLIService) showNegativeCycles() {
+	modal := tview.NewModal().
+		SetText("Search negative cycles by running Bellman-Ford from every vertex, or once from a virtual super-source?").
+		AddButtons([]string{"Every Vertex", "Super-Source", "Cancel"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			switch buttonLabel {
+			case "Every Vertex":
+				cli.executeNegativeCycles(algo.FindNegativeCycles)
+			case "Super-Source":
+				cli.executeNegativeCycles(algo.FindNegativeCyclesSuperSource)
+			case "Cancel":
+				cli.pages.SwitchToPage("algorithms_menu")
+			}
+		})
+
+	cli.pages.AddAndSwitchToPage("negative_cycles_mode", modal, true)
+}
+
+func (cli *CLIService) executeNegativeCycles(find func(*graph.Graph) (*algo.NegativeCyclesResult, error)) {
 	cli.updateStatus("Searching for negative cycles using Bellman-Ford algorithm...", Default)
 
 	go func() {
-		result, err := algo.FindNegativeCycles(cli.graph)
+		result, err := find(cli.graph)
 
 		cli.app.QueueUpdateDraw(func() {
 			var resultText string
@@ -419,28 +489,53 @@ func (cli *CLIService) showNegativeCycles() {
 
 func (cli *CLIService) showMaxFlowForm() {
 	form := tview.NewForm()
-	var sourceKey, sinkKey string
+	var sourceKeys, sinkKeys, nodeCapacities, exportFile string
+	algorithm := algo.MaxFlowAlgorithms[0]
 
-	form.AddInputField("Source Node Key", "", 10, nil, func(text string) {
-		sourceKey = text
+	algorithmNames := make([]string, len(algo.MaxFlowAlgorithms))
+	for i, name := range algo.MaxFlowAlgorithms {
+		algorithmNames[i] = string(name)
+	}
+
+	form.AddInputField("Source Node Keys (1, 2, ...)", "", 15, nil, func(text string) {
+		sourceKeys = text
 	})
-	form.AddInputField("Sink Node Key", "", 10, nil, func(text string) {
-		sinkKey = text
+	form.AddInputField("Sink Node Keys (1, 2, ...)", "", 15, nil, func(text string) {
+		sinkKeys = text
+	})
+	form.AddInputField("Node Capacities (key=capacity, ...)", "", 30, nil, func(text string) {
+		nodeCapacities = text
+	})
+	form.AddDropDown("Algorithm", algorithmNames, 0, func(option string, index int) {
+		algorithm = algo.MaxFlowAlgorithms[index]
+	})
+	form.AddInputField("Export JSON to (optional)", "", 30, nil, func(text string) {
+		exportFile = text
 	})
 	form.AddButton("Find Max Flow", func() {
-		sourceVal, err := strconv.ParseUint(sourceKey, 10, 64)
-		if err != nil {
+		sources, err := parseKeyList(sourceKeys)
+		if err != nil || len(sources) == 0 {
 			cli.updateStatus("Error: Invalid source key format", Error)
 			return
 		}
 
-		sinkVal, err := strconv.ParseUint(sinkKey, 10, 64)
-		if err != nil {
+		sinks, err := parseKeyList(sinkKeys)
+		if err != nil || len(sinks) == 0 {
 			cli.updateStatus("Error: Invalid sink key format", Error)
 			return
 		}
 
-		result, err := algo.FindMaxFlow(cli.graph, graph.TKey(sourceVal), graph.TKey(sinkVal))
+		capacities, err := parseNodeCapacities(nodeCapacities)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+
+		result, err := algo.FindMaxFlow(cli.graph, sources[0], sinks[0],
+			algo.WithMaxFlowAlgorithm(algorithm),
+			algo.WithMaxFlowSources(sources[1:]...),
+			algo.WithMaxFlowSinks(sinks[1:]...),
+			algo.WithNodeCapacities(capacities))
 
 		var resultText string
 		if err != nil {
@@ -448,7 +543,19 @@ func (cli *CLIService) showMaxFlowForm() {
 			cli.updateStatus("Max flow calculation failed", Error)
 		} else {
 			resultText = result.FormatMaxFlowResult(cli.graph)
-			cli.updateStatus(fmt.Sprintf("Max flow: %d from %d to %d", result.MaxFlowValue, sourceVal, sinkVal), Success)
+			cli.updateStatus(fmt.Sprintf("%s (%s)", result.Message, result.Algorithm), Success)
+
+			if exportFile != "" {
+				jsonData, err := result.ToJSON()
+				if err == nil {
+					err = os.WriteFile(exportFile, []byte(jsonData), 0644)
+				}
+				if err != nil {
+					cli.updateStatus(fmt.Sprintf("Error exporting flow: %v", err), Error)
+				} else {
+					cli.updateStatus(fmt.Sprintf("%s, exported to %s", result.Message, exportFile), Success)
+				}
+			}
 		}
 
 		cli.showScrollableModal("Maximum Flow", resultText, "algorithms_menu")
@@ -460,3 +567,1295 @@ func (cli *CLIService) showMaxFlowForm() {
 	form.SetBorder(true).SetTitle(" Find Maximum Flow ")
 	cli.pages.AddAndSwitchToPage("max_flow", form, true)
 }
+
+func parseKeyList(text string) ([]graph.TKey, error) {
+	keys := []graph.TKey{}
+	for _, field := range strings.Split(text, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		key, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q", field)
+		}
+		keys = append(keys, graph.TKey(key))
+	}
+	return keys, nil
+}
+
+func parseNodeCapacities(text string) (map[graph.TKey]graph.TWeight, error) {
+	capacities := make(map[graph.TKey]graph.TWeight)
+	for _, pair := range strings.Split(text, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		keyStr, capacityStr, found := strings.Cut(pair, "=")
+		key, keyErr := strconv.ParseUint(strings.TrimSpace(keyStr), 10, 64)
+		capacity, capacityErr := strconv.ParseInt(strings.TrimSpace(capacityStr), 10, 64)
+		if !found || keyErr != nil || capacityErr != nil {
+			return nil, fmt.Errorf("invalid node capacity %q, expected key=capacity", pair)
+		}
+		capacities[graph.TKey(key)] = graph.TWeight(capacity)
+	}
+	return capacities, nil
+}
+
+func parseNodeScores(text string) (map[graph.TKey]float64, error) {
+	scores := make(map[graph.TKey]float64)
+	for _, pair := range strings.Split(text, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		keyStr, scoreStr, found := strings.Cut(pair, "=")
+		key, keyErr := strconv.ParseUint(strings.TrimSpace(keyStr), 10, 64)
+		score, scoreErr := strconv.ParseFloat(strings.TrimSpace(scoreStr), 64)
+		if !found || keyErr != nil || scoreErr != nil {
+			return nil, fmt.Errorf("invalid node value %q, expected key=value", pair)
+		}
+		scores[graph.TKey(key)] = score
+	}
+	return scores, nil
+}
+
+func (cli *CLIService) showBiconnectivity() {
+	cli.updateStatus("Searching for bridges and articulation points...", Default)
+
+	go func() {
+		result, err := algo.FindBiconnectivity(cli.graph)
+
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			if err != nil {
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("Biconnectivity analysis failed", Error)
+			} else {
+				resultText = result.FormatBiconnectivityResult(cli.graph)
+				cli.updateStatus(result.Message, Success)
+			}
+
+			cli.showScrollableModal("Bridges and Articulation Points", resultText, "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showEulerianTrail() {
+	cli.updateStatus("Searching for Eulerian trail...", Default)
+
+	go func() {
+		result, err := algo.FindEulerianTrail(cli.graph)
+
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			if err != nil {
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("Eulerian trail search failed", Error)
+			} else {
+				resultText = result.FormatEulerianResult(cli.graph)
+				if result.HasPath {
+					cli.updateStatus(result.Message, Success)
+				} else {
+					cli.updateStatus(result.Message, Error)
+				}
+			}
+
+			cli.showScrollableModal("Eulerian Trail", resultText, "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showChinesePostman() {
+	cli.updateStatus("Searching for Chinese postman route...", Default)
+
+	go func() {
+		result, err := algo.FindChinesePostmanRoute(cli.graph)
+
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			if err != nil {
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("Chinese postman route search failed", Error)
+			} else {
+				resultText = result.FormatChinesePostmanResult(cli.graph)
+				cli.updateStatus(result.Message, Success)
+			}
+
+			cli.showScrollableModal("Chinese Postman", resultText, "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showArborescenceForm() {
+	form := tview.NewForm()
+	var rootKey string
+
+	form.AddInputField("Root Node Key", "", 10, nil, func(text string) {
+		rootKey = text
+	})
+	form.AddButton("Find Arborescence", func() {
+		rootVal, err := strconv.ParseUint(rootKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid root key format", Error)
+			return
+		}
+
+		result, err := algo.FindMinArborescence(cli.graph, graph.TKey(rootVal))
+
+		var resultText string
+		if err != nil {
+			resultText = fmt.Sprintf("Error: %v", err)
+			cli.updateStatus("Arborescence calculation failed", Error)
+		} else {
+			resultText = result.FormatArborescenceResult(cli.graph)
+			cli.updateStatus(result.Message, Success)
+		}
+
+		cli.showScrollableModal("Minimum Arborescence", resultText, "algorithms_menu")
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Minimum Spanning Arborescence ")
+	cli.pages.AddAndSwitchToPage("arborescence", form, true)
+}
+
+func (cli *CLIService) showBellmanFordForm() {
+	form := tview.NewForm()
+	algorithms := []string{"Bellman-Ford", "SPFA", "Dijkstra"}
+	algorithm := algorithms[0]
+	var sourceKey string
+
+	form.AddInputField("Source Node Key", "", 10, nil, func(text string) {
+		sourceKey = text
+	})
+	form.AddDropDown("Algorithm", algorithms, 0, func(option string, index int) {
+		algorithm = option
+	})
+	form.AddButton("Find Paths", func() {
+		sourceVal, err := strconv.ParseUint(sourceKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid source key format", Error)
+			return
+		}
+
+		var result *algo.BellmanFordResult
+		switch algorithm {
+		case "SPFA":
+			result, err = algo.SPFA(cli.graph, graph.TKey(sourceVal))
+		case "Dijkstra":
+			result, err = algo.Dijkstra(cli.graph, graph.TKey(sourceVal))
+		default:
+			result, err = algo.BellmanFord(cli.graph, graph.TKey(sourceVal))
+		}
+
+		var resultText string
+		if err != nil {
+			resultText = fmt.Sprintf("Error: %v", err)
+			cli.updateStatus("Shortest paths computation failed", Error)
+		} else {
+			resultText = result.FormatBellmanFordResult(cli.graph)
+			if result.HasNegativeCycle {
+				cli.updateStatus(result.Message, Error)
+			} else {
+				cli.updateStatus(result.Message, Success)
+			}
+		}
+
+		cli.showScrollableModal("Shortest Paths from Source", resultText, "algorithms_menu")
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Single-Source Shortest Paths ")
+	cli.pages.AddAndSwitchToPage("bellman_ford", form, true)
+}
+
+func (cli *CLIService) showMinCostFlowForm() {
+	form := tview.NewForm()
+	var sourceKey, sinkKey, targetStr string
+	capacityField, costField := algo.EdgeFieldWeight, "cost"
+	costScaling := false
+
+	form.AddInputField("Source Node Key", "", 10, nil, func(text string) {
+		sourceKey = text
+	})
+	form.AddInputField("Sink Node Key", "", 10, nil, func(text string) {
+		sinkKey = text
+	})
+	form.AddInputField("Capacity Field", capacityField, 15, nil, func(text string) {
+		capacityField = text
+	})
+	form.AddInputField("Cost Field", costField, 15, nil, func(text string) {
+		costField = text
+	})
+	form.AddInputField("Target Flow (empty = max)", "", 10, nil, func(text string) {
+		targetStr = text
+	})
+	form.AddCheckbox("Cost Scaling", false, func(checked bool) {
+		costScaling = checked
+	})
+	form.AddButton("Find Flow", func() {
+		sourceVal, err := strconv.ParseUint(sourceKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid source key format", Error)
+			return
+		}
+
+		sinkVal, err := strconv.ParseUint(sinkKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid sink key format", Error)
+			return
+		}
+
+		target := uint64(0)
+		if targetStr != "" {
+			if target, err = strconv.ParseUint(targetStr, 10, 64); err != nil {
+				cli.updateStatus("Error: Invalid target flow format", Error)
+				return
+			}
+		}
+
+		cli.updateStatus("Computing minimum-cost flow...", Default)
+
+		go func() {
+			result, err := algo.FindMinCostFlow(cli.graph, graph.TKey(sourceVal), graph.TKey(sinkVal),
+				algo.WithCapacityField(capacityField),
+				algo.WithCostField(costField),
+				algo.WithTargetFlow(graph.TWeight(target)),
+				algo.WithCostScaling(costScaling))
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Minimum-cost flow calculation failed", Error)
+				} else {
+					resultText = result.FormatMinCostFlowResult(cli.graph)
+					if result.IsTargetReached {
+						cli.updateStatus(result.Message, Success)
+					} else {
+						cli.updateStatus(result.Message, Error)
+					}
+				}
+
+				cli.showScrollableModal("Minimum-Cost Flow", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Minimum-Cost Flow ")
+	cli.pages.AddAndSwitchToPage("min_cost_flow", form, true)
+}
+
+func (cli *CLIService) showGlobalMinCut() {
+	cli.updateStatus("Searching for global minimum cut...", Default)
+
+	go func() {
+		result, err := algo.FindGlobalMinCut(cli.graph)
+
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			if err != nil {
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("Global minimum cut search failed", Error)
+			} else {
+				resultText = result.FormatStoerWagnerResult(cli.graph)
+				cli.updateStatus(result.Message, Success)
+			}
+
+			cli.showScrollableModal("Global Minimum Cut", resultText, "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showGomoryHuTree() {
+	cli.updateStatus("Building Gomory-Hu tree...", Default)
+
+	go func() {
+		tree, err := algo.FindGomoryHuTree(cli.graph)
+
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			if err != nil {
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("Gomory-Hu tree construction failed", Error)
+			} else {
+				resultText = algo.FormatGomoryHuTree(cli.graph, tree)
+				cli.updateStatus(fmt.Sprintf("Gomory-Hu tree built with %d edges", len(tree.Edges)), Success)
+			}
+
+			cli.showScrollableModal("Gomory-Hu Tree", resultText, "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showConnectivityForm() {
+	form := tview.NewForm()
+	var sourceKey, sinkKey string
+	vertexDisjoint := false
+
+	form.AddInputField("Source Node Key", "", 10, nil, func(text string) {
+		sourceKey = text
+	})
+	form.AddInputField("Sink Node Key", "", 10, nil, func(text string) {
+		sinkKey = text
+	})
+	form.AddDropDown("Paths", []string{"Edge-disjoint", "Vertex-disjoint"}, 0, func(option string, index int) {
+		vertexDisjoint = index == 1
+	})
+	form.AddButton("Disjoint Paths", func() {
+		sourceVal, err := strconv.ParseUint(sourceKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid source key format", Error)
+			return
+		}
+
+		sinkVal, err := strconv.ParseUint(sinkKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid sink key format", Error)
+			return
+		}
+
+		result, err := algo.FindDisjointPaths(cli.graph, graph.TKey(sourceVal), graph.TKey(sinkVal), vertexDisjoint)
+
+		var resultText string
+		if err != nil {
+			resultText = fmt.Sprintf("Error: %v", err)
+			cli.updateStatus("Disjoint paths search failed", Error)
+		} else {
+			resultText = result.FormatDisjointPathsResult(cli.graph)
+			cli.updateStatus(result.Message, Success)
+		}
+
+		cli.showScrollableModal("Disjoint Paths", resultText, "algorithms_menu")
+	})
+	form.AddButton("Graph Connectivity", func() {
+		cli.updateStatus("Computing edge and vertex connectivity...", Default)
+
+		go func() {
+			result, err := algo.FindConnectivity(cli.graph)
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Connectivity computation failed", Error)
+				} else {
+					resultText = result.FormatConnectivityResult(cli.graph)
+					cli.updateStatus(result.Message, Success)
+				}
+
+				cli.showScrollableModal("Graph Connectivity", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Connectivity and Disjoint Paths ")
+	cli.pages.AddAndSwitchToPage("connectivity", form, true)
+}
+
+func (cli *CLIService) showBipartiteMatching() {
+	cli.updateStatus("Checking bipartiteness...", Default)
+
+	go func() {
+		result, err := algo.IsBipartite(cli.graph)
+
+		var matching *algo.MatchingResult
+		if err == nil && result.IsBipartite {
+			matching, err = algo.FindBipartiteMatching(cli.graph)
+		}
+
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			switch {
+			case err != nil:
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("Bipartite matching failed", Error)
+			case matching == nil:
+				resultText = result.FormatBipartiteResult(cli.graph)
+				cli.updateStatus(result.Message, Error)
+			default:
+				resultText = result.FormatBipartiteResult(cli.graph) + "\n" + matching.FormatMatchingResult(cli.graph)
+				cli.updateStatus(matching.Message, Success)
+			}
+
+			cli.showScrollableModal("Bipartite Matching", resultText, "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showWeightedMatchingForm() {
+	form := tview.NewForm()
+	maximize := false
+
+	form.AddDropDown("Assignment weight", []string{"Minimum", "Maximum"}, 0, func(option string, index int) {
+		maximize = index == 1
+	})
+	form.AddButton("Assignment (Hungarian)", func() {
+		cli.updateStatus("Searching for weighted assignment...", Default)
+
+		go func() {
+			result, err := algo.FindAssignment(cli.graph, algo.WithAssignmentMaximize(maximize))
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Assignment search failed", Error)
+				} else {
+					resultText = result.FormatAssignmentResult(cli.graph)
+					cli.updateStatus(result.Message, Success)
+				}
+
+				cli.showScrollableModal("Weighted Assignment", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Maximum Matching (Blossom)", func() {
+		cli.updateStatus("Searching for maximum matching...", Default)
+
+		go func() {
+			result, err := algo.FindMaximumMatching(cli.graph)
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Maximum matching search failed", Error)
+				} else {
+					resultText = result.FormatMatchingResult(cli.graph)
+					cli.updateStatus(result.Message, Success)
+				}
+
+				cli.showScrollableModal("Maximum Matching", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Weighted Matching ")
+	cli.pages.AddAndSwitchToPage("weighted_matching", form, true)
+}
+
+func (cli *CLIService) showColoringForm() {
+	form := tview.NewForm()
+	timeoutText := "5"
+	var exportFile string
+
+	methods := []string{"Exact (branch and bound)"}
+	for _, order := range algo.ColoringOrders {
+		methods = append(methods, string(order))
+	}
+	method := 0
+
+	form.AddDropDown("Vertex coloring", methods, 0, func(option string, index int) {
+		method = index
+	})
+	form.AddInputField("Exact search timeout (seconds)", timeoutText, 10, nil, func(text string) {
+		timeoutText = text
+	})
+	form.AddInputField("Export DOT to (optional)", "", 30, nil, func(text string) {
+		exportFile = text
+	})
+	form.AddButton("Color Vertices", func() {
+		timeout, err := strconv.ParseFloat(timeoutText, 64)
+		if err != nil || timeout < 0 {
+			cli.updateStatus("Error: Invalid timeout format", Error)
+			return
+		}
+
+		cli.updateStatus("Coloring vertices...", Default)
+
+		go func() {
+			var result *algo.ColoringResult
+			var err error
+			if method == 0 {
+				result, err = algo.FindChromaticNumber(cli.graph, time.Duration(timeout*float64(time.Second)))
+			} else {
+				result, err = algo.GreedyColoring(cli.graph, algo.ColoringOrders[method-1])
+			}
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Vertex coloring failed", Error)
+				} else {
+					resultText = result.FormatColoringResult(cli.graph)
+					cli.updateStatus(result.Message, Success)
+					cli.exportDOT(exportFile, result.Message, graph.WithDOTNodeColors(result.Colors))
+				}
+
+				cli.showScrollableModal("Vertex Coloring", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Color Edges", func() {
+		result, err := algo.FindEdgeColoring(cli.graph)
+
+		var resultText string
+		if err != nil {
+			resultText = fmt.Sprintf("Error: %v", err)
+			cli.updateStatus("Edge coloring failed", Error)
+		} else {
+			resultText = result.FormatEdgeColoringResult(cli.graph)
+			cli.updateStatus(result.Message, Success)
+			cli.exportDOT(exportFile, result.Message, graph.WithDOTEdgeColors(result.Colors))
+		}
+
+		cli.showScrollableModal("Edge Coloring", resultText, "algorithms_menu")
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Graph Coloring ")
+	cli.pages.AddAndSwitchToPage("coloring", form, true)
+}
+
+func (cli *CLIService) exportDOT(filename, message string, options ...graph.Option[graph.DOTOptions]) {
+	if filename == "" {
+		return
+	}
+
+	if err := os.WriteFile(filename, []byte(cli.graph.ToDOT(options...)), 0644); err != nil {
+		cli.updateStatus(fmt.Sprintf("Error exporting DOT: %v", err), Error)
+	} else {
+		cli.updateStatus(fmt.Sprintf("%s, exported to %s", message, filename), Success)
+	}
+}
+
+func (cli *CLIService) showCliquesAndCovers() {
+	cli.updateStatus("Searching for cliques, independent sets and covers...", Default)
+
+	go func() {
+		var sb strings.Builder
+		cliques, err := algo.FindMaximalCliques(cli.graph)
+
+		var independent, cover *algo.VertexSetResult
+		if err == nil {
+			independent, err = algo.FindMaximumIndependentSet(cli.graph)
+		}
+		if err == nil {
+			cover, err = algo.FindMinimumVertexCover(cli.graph)
+		}
+
+		cli.app.QueueUpdateDraw(func() {
+			if err != nil {
+				sb.WriteString(fmt.Sprintf("Error: %v", err))
+				cli.updateStatus("Clique search failed", Error)
+			} else {
+				sb.WriteString(independent.FormatVertexSetResult("Maximum independent set"))
+				sb.WriteString("\n")
+				sb.WriteString(cover.FormatVertexSetResult("Minimum vertex cover"))
+				sb.WriteString("\n")
+				sb.WriteString(cliques.FormatCliquesResult(cli.graph))
+				cli.updateStatus(cliques.Message, Success)
+			}
+
+			cli.showScrollableModal("Cliques and Vertex Covers", sb.String(), "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showCentralityForm() {
+	form := tview.NewForm()
+	measures := []algo.CentralityMeasure{
+		algo.CentralityBetweenness, algo.CentralityEdgeBetweenness, algo.CentralityCloseness, algo.CentralityHarmonic,
+		algo.CentralityPageRank, algo.CentralityEigenvector, algo.CentralityHubs, algo.CentralityAuthorities,
+	}
+	measureNames := make([]string, len(measures))
+	for i, measure := range measures {
+		measureNames[i] = string(measure)
+	}
+
+	measure := measures[0]
+	var weighted, normalized bool
+	dampingText := "0.85"
+	var personalizationText string
+
+	form.AddDropDown("Measure", measureNames, 0, func(option string, index int) {
+		measure = measures[index]
+	})
+	form.AddCheckbox("Use edge weights", false, func(checked bool) {
+		weighted = checked
+	})
+	form.AddCheckbox("Normalized", false, func(checked bool) {
+		normalized = checked
+	})
+	form.AddInputField("PageRank damping", dampingText, 10, nil, func(text string) {
+		dampingText = text
+	})
+	form.AddInputField("PageRank personalization (key=value, ...)", "", 30, nil, func(text string) {
+		personalizationText = text
+	})
+	form.AddButton("Rank", func() {
+		damping, err := strconv.ParseFloat(dampingText, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid damping format", Error)
+			return
+		}
+
+		personalization, err := parseNodeScores(personalizationText)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+
+		options := []graph.Option[algo.CentralityOptions]{
+			algo.WithCentralityWeighted(weighted),
+			algo.WithCentralityNormalized(normalized),
+			algo.WithDamping(damping),
+			algo.WithPersonalization(personalization),
+		}
+
+		cli.updateStatus(fmt.Sprintf("Computing %s centrality...", measure), Default)
+
+		go func() {
+			var result *algo.CentralityResult
+			var err error
+			switch measure {
+			case algo.CentralityBetweenness:
+				result, err = algo.FindBetweenness(cli.graph, options...)
+			case algo.CentralityEdgeBetweenness:
+				result, err = algo.FindEdgeBetweenness(cli.graph, options...)
+			case algo.CentralityCloseness:
+				result, err = algo.FindCloseness(cli.graph, options...)
+			case algo.CentralityHarmonic:
+				result, err = algo.FindHarmonicCentrality(cli.graph, options...)
+			case algo.CentralityPageRank:
+				result, err = algo.FindPageRank(cli.graph, options...)
+			case algo.CentralityEigenvector:
+				result, err = algo.FindEigenvectorCentrality(cli.graph, options...)
+			case algo.CentralityHubs:
+				result, _, err = algo.FindHITS(cli.graph, options...)
+			case algo.CentralityAuthorities:
+				_, result, err = algo.FindHITS(cli.graph, options...)
+			}
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Centrality computation failed", Error)
+				} else {
+					resultText = result.FormatCentralityResult(cli.graph)
+					cli.updateStatus(result.Message, Success)
+				}
+
+				cli.showScrollableModal("Centrality", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Centrality ")
+	cli.pages.AddAndSwitchToPage("centrality", form, true)
+}
+
+func (cli *CLIService) showCommunitiesForm() {
+	form := tview.NewForm()
+	algorithms := []string{"Louvain", "Label propagation"}
+
+	algorithm := algorithms[0]
+	weighted := true
+	resolutionText := "1"
+	seedText := "1"
+	var replace bool
+
+	form.AddDropDown("Algorithm", algorithms, 0, func(option string, index int) {
+		algorithm = option
+	})
+	form.AddCheckbox("Use edge weights", true, func(checked bool) {
+		weighted = checked
+	})
+	form.AddInputField("Resolution", resolutionText, 10, nil, func(text string) {
+		resolutionText = text
+	})
+	form.AddInputField("Seed", seedText, 20, nil, func(text string) {
+		seedText = text
+	})
+	form.AddCheckbox("Replace graph with quotient graph", false, func(checked bool) {
+		replace = checked
+	})
+	form.AddButton("Find", func() {
+		resolution, err := strconv.ParseFloat(resolutionText, 64)
+		if err != nil || resolution <= 0 {
+			cli.updateStatus("Error: Resolution must be a positive number", Error)
+			return
+		}
+
+		seed, err := strconv.ParseUint(seedText, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid seed format", Error)
+			return
+		}
+
+		options := []graph.Option[algo.CommunityOptions]{
+			algo.WithCommunityWeighted(weighted),
+			algo.WithResolution(resolution),
+			algo.WithCommunitySeed(seed),
+		}
+
+		cli.updateStatus(fmt.Sprintf("Running %s...", algorithm), Default)
+
+		go func() {
+			var result *algo.CommunityResult
+			var err error
+			if algorithm == "Louvain" {
+				result, err = algo.FindCommunitiesLouvain(cli.graph, options...)
+			} else {
+				result, err = algo.FindCommunitiesLabelPropagation(cli.graph, options...)
+			}
+
+			var quotient *graph.Graph
+			if err == nil && replace {
+				quotient, err = result.QuotientGraph(cli.graph)
+			}
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Community detection failed", Error)
+				} else {
+					resultText = result.FormatCommunityResult(cli.graph)
+					if quotient != nil {
+						cli.graph = quotient
+						resultText += fmt.Sprintf("\nGraph replaced with quotient graph: %d nodes, %d edges\n", len(quotient.Nodes), len(quotient.Edges))
+					}
+					cli.updateStatus(result.Message, Success)
+				}
+
+				cli.showScrollableModal("Communities", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Community Detection ")
+	cli.pages.AddAndSwitchToPage("communities", form, true)
+}
+
+func (cli *CLIService) showKCoreForm() {
+	form := tview.NewForm()
+	var kText string
+
+	form.AddInputField("K (empty to keep graph)", "", 10, nil, func(text string) {
+		kText = text
+	})
+	form.AddButton("Decompose", func() {
+		result, err := algo.FindCoreDecomposition(cli.graph)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+		resultText := result.FormatCoresResult(cli.graph)
+
+		if kText != "" {
+			k, err := strconv.Atoi(kText)
+			if err != nil || k < 0 {
+				cli.updateStatus("Error: K must be a non-negative integer", Error)
+				return
+			}
+
+			subgraph, err := algo.KCoreSubgraph(cli.graph, k)
+			if err != nil {
+				cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+				return
+			}
+			cli.graph = subgraph
+			resultText += fmt.Sprintf("\nGraph replaced with its %d-core: %d nodes, %d edges\n", k, len(subgraph.Nodes), len(subgraph.Edges))
+		}
+
+		cli.showScrollableModal("K-Core Decomposition", resultText, "algorithms_menu")
+		cli.updateStatus(result.Message, Success)
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" K-Core Decomposition ")
+	cli.pages.AddAndSwitchToPage("k_core", form, true)
+}
+
+func (cli *CLIService) showIsomorphismForm() {
+	if cli.pattern == nil {
+		cli.updateStatus("Error: Load a pattern graph first (JSON Operations → Load Pattern)", Error)
+		return
+	}
+
+	form := tview.NewForm()
+	modes := []string{"Isomorphism", "Induced subgraph", "Subgraph (extra edges allowed)"}
+
+	mode := 0
+	var matchNodeLabels, matchEdgeLabels, matchEdgeWeights bool
+	limitText := strconv.Itoa(algo.DefaultMaxMappings)
+
+	form.AddDropDown("Mode", modes, 0, func(option string, index int) {
+		mode = index
+	})
+	form.AddCheckbox("Match node labels", false, func(checked bool) {
+		matchNodeLabels = checked
+	})
+	form.AddCheckbox("Match edge labels", false, func(checked bool) {
+		matchEdgeLabels = checked
+	})
+	form.AddCheckbox("Match edge weights", false, func(checked bool) {
+		matchEdgeWeights = checked
+	})
+	form.AddInputField("Max mappings (0 for all)", limitText, 10, nil, func(text string) {
+		limitText = text
+	})
+	form.AddButton("Match", func() {
+		limit, err := strconv.Atoi(limitText)
+		if err != nil || limit < 0 {
+			cli.updateStatus("Error: Max mappings must be a non-negative integer", Error)
+			return
+		}
+
+		options := []graph.Option[algo.MatchOptions]{
+			algo.WithMaxMappings(limit),
+			algo.WithInduced(mode != 2),
+		}
+		if matchNodeLabels {
+			options = append(options, algo.WithNodeMatch(algo.MatchNodeLabels))
+		}
+		if matchEdgeLabels || matchEdgeWeights {
+			options = append(options, algo.WithEdgeMatch(func(patternEdge, targetEdge *graph.Edge) bool {
+				return (!matchEdgeLabels || algo.MatchEdgeLabels(patternEdge, targetEdge)) &&
+					(!matchEdgeWeights || algo.MatchEdgeWeights(patternEdge, targetEdge))
+			}))
+		}
+
+		cli.updateStatus(fmt.Sprintf("Matching pattern (%s)...", modes[mode]), Default)
+
+		go func() {
+			var result *algo.IsomorphismResult
+			var err error
+			if mode == 0 {
+				result, err = algo.FindIsomorphisms(cli.pattern, cli.graph, options...)
+			} else {
+				result, err = algo.FindSubgraphMatches(cli.pattern, cli.graph, options...)
+			}
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Matching failed", Error)
+				} else {
+					resultText = result.FormatIsomorphismResult(cli.pattern, cli.graph)
+					cli.updateStatus(result.Message, Success)
+				}
+
+				cli.showScrollableModal("Isomorphism and Subgraph Matching", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Isomorphism and Subgraph Matching ")
+	cli.pages.AddAndSwitchToPage("isomorphism", form, true)
+}
+
+func (cli *CLIService) showPlanarity() {
+	cli.updateStatus("Testing planarity...", Default)
+
+	go func() {
+		result, err := algo.TestPlanarity(cli.graph)
+
+		cli.app.QueueUpdateDraw(func() {
+			var resultText string
+			switch {
+			case err != nil:
+				resultText = fmt.Sprintf("Error: %v", err)
+				cli.updateStatus("Planarity test failed", Error)
+			case result.IsPlanar:
+				resultText = result.FormatPlanarityResult(cli.graph)
+				cli.updateStatus(result.Message, Success)
+			default:
+				resultText = result.FormatPlanarityResult(cli.graph)
+				cli.updateStatus(result.Message, Error)
+			}
+
+			cli.showScrollableModal("Planarity Test", resultText, "algorithms_menu")
+		})
+	}()
+}
+
+func (cli *CLIService) showDominatorsForm() {
+	form := tview.NewForm()
+	var rootKey string
+	var post, replace bool
+
+	form.AddInputField("Entry Node Key (exit for post-dominators)", "", 10, nil, func(text string) {
+		rootKey = text
+	})
+	form.AddCheckbox("Post-dominators", false, func(checked bool) {
+		post = checked
+	})
+	form.AddCheckbox("Replace graph with dominator tree", false, func(checked bool) {
+		replace = checked
+	})
+	form.AddButton("Find", func() {
+		keyVal, err := strconv.ParseUint(rootKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid key format", Error)
+			return
+		}
+
+		var result *algo.DominatorResult
+		if post {
+			result, err = algo.FindPostDominators(cli.graph, graph.TKey(keyVal))
+		} else {
+			result, err = algo.FindDominators(cli.graph, graph.TKey(keyVal))
+		}
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+
+		resultText := result.FormatDominatorResult(cli.graph)
+		if replace {
+			cli.graph = result.Tree
+			resultText += fmt.Sprintf("\nGraph replaced with dominator tree: %d nodes, %d edges\n", len(result.Tree.Nodes), len(result.Tree.Edges))
+		}
+
+		cli.showScrollableModal("Dominator Tree", resultText, "algorithms_menu")
+		cli.updateStatus(result.Message, Success)
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Dominator Tree ")
+	cli.pages.AddAndSwitchToPage("dominators", form, true)
+}
+
+func (cli *CLIService) showKShortestPathsForm() {
+	form := tview.NewForm()
+	modes := []string{"K shortest paths (Yen)", "All shortest paths (DAG)"}
+
+	mode := 0
+	var sourceKey, targetKey string
+	kText := "5"
+	var replace bool
+
+	form.AddInputField("Source Node Key", "", 10, nil, func(text string) {
+		sourceKey = text
+	})
+	form.AddInputField("Target Node Key", "", 10, nil, func(text string) {
+		targetKey = text
+	})
+	form.AddDropDown("Mode", modes, 0, func(option string, index int) {
+		mode = index
+	})
+	form.AddInputField("K (paths to list)", kText, 10, nil, func(text string) {
+		kText = text
+	})
+	form.AddCheckbox("Replace graph with shortest path DAG", false, func(checked bool) {
+		replace = checked
+	})
+	form.AddButton("Find", func() {
+		sourceVal, err := strconv.ParseUint(sourceKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid source key format", Error)
+			return
+		}
+		targetVal, err := strconv.ParseUint(targetKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid target key format", Error)
+			return
+		}
+		k, err := strconv.Atoi(kText)
+		if err != nil || k < 1 {
+			cli.updateStatus("Error: K must be a positive integer", Error)
+			return
+		}
+
+		cli.updateStatus(fmt.Sprintf("Searching %s...", modes[mode]), Default)
+
+		go func() {
+			var resultText, message string
+			var dag *graph.Graph
+			var err error
+			if mode == 0 {
+				var result *algo.KShortestPathsResult
+				result, err = algo.FindKShortestPaths(cli.graph, graph.TKey(sourceVal), graph.TKey(targetVal), k)
+				if err == nil {
+					resultText, message = result.FormatKShortestPathsResult(cli.graph), result.Message
+				}
+			} else {
+				var result *algo.ShortestPathDAGResult
+				result, err = algo.FindShortestPathDAG(cli.graph, graph.TKey(sourceVal), graph.TKey(targetVal))
+				if err == nil {
+					resultText, message, dag = result.FormatShortestPathDAGResult(cli.graph, k), result.Message, result.DAG
+				}
+			}
+
+			cli.app.QueueUpdateDraw(func() {
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus("Shortest paths search failed", Error)
+				} else {
+					if replace && dag != nil {
+						cli.graph = dag
+						resultText += fmt.Sprintf("\nGraph replaced with shortest path DAG: %d nodes, %d edges\n", len(dag.Nodes), len(dag.Edges))
+					}
+					cli.updateStatus(message, Success)
+				}
+
+				cli.showScrollableModal("K Shortest Paths", resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" K Shortest Paths ")
+	cli.pages.AddAndSwitchToPage("k_shortest_paths", form, true)
+}
+
+func (cli *CLIService) showPointToPointForm() {
+	form := tview.NewForm()
+	algorithms := []string{"Bidirectional Dijkstra", "Contraction hierarchy"}
+
+	algorithm := 0
+	var sourceKey, targetKey string
+	filename := "hierarchy.json"
+
+	form.AddInputField("Source Node Key", "", 10, nil, func(text string) {
+		sourceKey = text
+	})
+	form.AddInputField("Target Node Key", "", 10, nil, func(text string) {
+		targetKey = text
+	})
+	form.AddDropDown("Algorithm", algorithms, 0, func(option string, index int) {
+		algorithm = index
+	})
+	form.AddInputField("Index File", filename, 30, nil, func(text string) {
+		filename = text
+	})
+	form.AddButton("Query", func() {
+		sourceVal, err := strconv.ParseUint(sourceKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid source key format", Error)
+			return
+		}
+		targetVal, err := strconv.ParseUint(targetKey, 10, 64)
+		if err != nil {
+			cli.updateStatus("Error: Invalid target key format", Error)
+			return
+		}
+		if algorithm == 1 && (cli.hierarchy == nil || cli.hierarchy.Graph() != cli.graph) {
+			cli.updateStatus("Error: Build or load contraction hierarchy for this graph first", Error)
+			return
+		}
+
+		var result *algo.PointToPointResult
+		start := time.Now()
+		if algorithm == 0 {
+			result, err = algo.BidirectionalDijkstra(cli.graph, graph.TKey(sourceVal), graph.TKey(targetVal))
+		} else {
+			result, err = cli.hierarchy.Query(graph.TKey(sourceVal), graph.TKey(targetVal))
+		}
+		elapsed := time.Since(start)
+
+		var resultText string
+		if err != nil {
+			resultText = fmt.Sprintf("Error: %v", err)
+			cli.updateStatus("Shortest path query failed", Error)
+		} else {
+			resultText = result.FormatPointToPointResult(cli.graph) + fmt.Sprintf("\nQuery time: %v\n", elapsed)
+			cli.updateStatus(result.Message, Success)
+		}
+		cli.showScrollableModal("Point-to-Point Query", resultText, "point_to_point")
+	})
+	form.AddButton("Build Index", func() {
+		cli.updateStatus("Building contraction hierarchy...", Default)
+
+		gr := cli.graph
+		go func() {
+			start := time.Now()
+			hierarchy, err := algo.BuildContractionHierarchy(gr)
+			elapsed := time.Since(start)
+
+			cli.app.QueueUpdateDraw(func() {
+				if err != nil {
+					cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+					return
+				}
+				cli.hierarchy = hierarchy
+				cli.updateStatus(fmt.Sprintf("Contraction hierarchy built in %v: %d shortcuts added", elapsed, hierarchy.Shortcuts()), Success)
+			})
+		}()
+	})
+	form.AddButton("Save Index", func() {
+		if cli.hierarchy == nil || cli.hierarchy.Graph() != cli.graph {
+			cli.updateStatus("Error: No contraction hierarchy for this graph", Error)
+			return
+		}
+		if !cli.hierarchy.Valid() {
+			cli.updateStatus("Error: Graph was changed, rebuild contraction hierarchy", Error)
+			return
+		}
+		file, err := os.Create(filename)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error creating file: %v", err), Error)
+			return
+		}
+		defer file.Close()
+		if err := cli.hierarchy.Save(file); err != nil {
+			cli.updateStatus(fmt.Sprintf("Error saving index: %v", err), Error)
+			return
+		}
+		cli.updateStatus(fmt.Sprintf("Contraction hierarchy saved to %s", filename), Success)
+	})
+	form.AddButton("Load Index", func() {
+		file, err := os.Open(filename)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error opening file: %v", err), Error)
+			return
+		}
+		defer file.Close()
+		hierarchy, err := algo.LoadContractionHierarchy(file, cli.graph)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+		cli.hierarchy = hierarchy
+		cli.updateStatus(fmt.Sprintf("Contraction hierarchy loaded from %s", filename), Success)
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Fast Point-to-Point Queries ")
+	cli.pages.AddAndSwitchToPage("point_to_point", form, true)
+}
+
+func (cli *CLIService) showReachabilityForm() {
+	form := tview.NewForm()
+	modes := []string{"Reachability query", "Transitive closure", "Transitive reduction"}
+
+	mode := 0
+	var sourceKey, targetKey string
+	var replace bool
+
+	form.AddDropDown("Mode", modes, 0, func(option string, index int) {
+		mode = index
+	})
+	form.AddInputField("Source Node Key (query)", "", 10, nil, func(text string) {
+		sourceKey = text
+	})
+	form.AddInputField("Target Node Key (query)", "", 10, nil, func(text string) {
+		targetKey = text
+	})
+	form.AddCheckbox("Replace graph with closure or reduction", false, func(checked bool) {
+		replace = checked
+	})
+	form.AddButton("Run", func() {
+		if mode == 0 {
+			sourceVal, err := strconv.ParseUint(sourceKey, 10, 64)
+			if err != nil {
+				cli.updateStatus("Error: Invalid source key format", Error)
+				return
+			}
+			targetVal, err := strconv.ParseUint(targetKey, 10, 64)
+			if err != nil {
+				cli.updateStatus("Error: Invalid target key format", Error)
+				return
+			}
+			cli.executeReachabilityQuery(graph.TKey(sourceVal), graph.TKey(targetVal))
+			return
+		}
+
+		cli.updateStatus(fmt.Sprintf("Building %s...", strings.ToLower(modes[mode])), Default)
+
+		go func() {
+			var result *algo.TransitiveResult
+			var err error
+			if mode == 1 {
+				result, err = algo.TransitiveClosure(cli.graph)
+			} else {
+				result, err = algo.TransitiveReduction(cli.graph)
+			}
+
+			cli.app.QueueUpdateDraw(func() {
+				var resultText string
+				if err != nil {
+					resultText = fmt.Sprintf("Error: %v", err)
+					cli.updateStatus(fmt.Sprintf("%s failed", modes[mode]), Error)
+				} else {
+					resultText = result.FormatTransitiveResult(cli.graph)
+					if replace {
+						cli.graph = result.Graph
+						resultText += fmt.Sprintf("\nGraph replaced with transitive %s: %d nodes, %d edges\n",
+							strings.ToLower(result.Kind), len(result.Graph.Nodes), len(result.Graph.Edges))
+					}
+					cli.updateStatus(result.Message, Success)
+				}
+
+				cli.showScrollableModal(modes[mode], resultText, "algorithms_menu")
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("algorithms_menu")
+	})
+
+	form.SetBorder(true).SetTitle(" Reachability and Transitive Closure ")
+	cli.pages.AddAndSwitchToPage("reachability", form, true)
+}
+
+func (cli *CLIService) executeReachabilityQuery(source, target graph.TKey) {
+	// Index is reused between queries until graph is replaced or changed
+	if cli.reachability == nil || cli.reachability.Graph() != cli.graph || !cli.reachability.Valid() {
+		index, err := algo.BuildReachabilityIndex(cli.graph)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+		cli.reachability = index
+	}
+
+	reachable, err := cli.reachability.CanReach(source, target)
+	if err != nil {
+		cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+		return
+	}
+
+	var sb strings.Builder
+	verdict := "cannot reach"
+	if reachable {
+		verdict = "can reach"
+	}
+	sb.WriteString(fmt.Sprintf("Node %d %s node %d\n\n", source, verdict, target))
+	sb.WriteString(cli.reachability.FormatReachabilityIndex(cli.graph))
+
+	cli.updateStatus(fmt.Sprintf("Node %d %s node %d", source, verdict, target), Success)
+	cli.showScrollableModal("Reachability", sb.String(), "reachability")
+}
@@ -9,7 +9,10 @@ package cli
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/rivo/tview"
 	"github.com/tolstovrob/graph-go/graph"
@@ -38,7 +41,7 @@ func (cli *CLIService) showEdgeOperations() {
 }
 func (cli *CLIService) showAddEdgeForm() {
 	form := tview.NewForm()
-	var edgeKey, srcKey, dstKey, weightStr, label string
+	var edgeKey, srcKey, dstKey, weightStr, label, attributes string
 
 	form.AddInputField("Edge Key", "", 10, nil, func(text string) {
 		edgeKey = text
@@ -55,6 +58,9 @@ func (cli *CLIService) showAddEdgeForm() {
 	form.AddInputField("Label", "", 20, nil, func(text string) {
 		label = text
 	})
+	form.AddInputField("Attributes (name=value, ...)", "", 30, nil, func(text string) {
+		attributes = text
+	})
 	form.AddButton("Add", func() {
 		key, err := strconv.ParseUint(edgeKey, 10, 64)
 		if err != nil {
@@ -80,7 +86,13 @@ func (cli *CLIService) showAddEdgeForm() {
 			return
 		}
 
-		edge := graph.MakeEdge(graph.TKey(key), graph.TKey(src), graph.TKey(dst))
+		attributeOptions, err := parseEdgeAttributes(attributes)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+
+		edge := graph.MakeEdge(graph.TKey(key), graph.TKey(src), graph.TKey(dst), attributeOptions...)
 		if weight > 0 {
 			edge.UpdateEdge(graph.WithEdgeWeight(graph.TWeight(weight)))
 		}
@@ -134,7 +146,7 @@ func (cli *CLIService) showRemoveEdgeForm() {
 
 func (cli *CLIService) showModifyEdgeForm() {
 	form := tview.NewForm()
-	var key, weightStr, label string
+	var key, weightStr, label, attributes string
 
 	form.AddInputField("Edge Key", "", 10, nil, func(text string) {
 		key = text
@@ -145,6 +157,9 @@ func (cli *CLIService) showModifyEdgeForm() {
 	form.AddInputField("New Label", "", 20, nil, func(text string) {
 		label = text
 	})
+	form.AddInputField("Set Attributes (name=value, ...)", "", 30, nil, func(text string) {
+		attributes = text
+	})
 	form.AddButton("Modify", func() {
 		keyVal, err := strconv.ParseUint(key, 10, 64)
 		if err != nil {
@@ -171,6 +186,14 @@ func (cli *CLIService) showModifyEdgeForm() {
 			edge.UpdateEdge(graph.WithEdgeLabel(label))
 		}
 
+		attributeOptions, err := parseEdgeAttributes(attributes)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error: %v", err), Error)
+			return
+		}
+		edge.UpdateEdge(attributeOptions...)
+		cli.graph.Touch()
+
 		cli.updateStatus(fmt.Sprintf("Edge %d modified successfully", keyVal), Success)
 		cli.pages.SwitchToPage("main")
 	})
@@ -185,9 +208,36 @@ func (cli *CLIService) showModifyEdgeForm() {
 func (cli *CLIService) showEdgesList() {
 	edgesInfo := "Edges:\n\n"
 	for key, edge := range cli.graph.Edges {
-		edgesInfo += fmt.Sprintf("Key: %d, Source: %d -> Destination: %d, Weight: %d, Label: %s\n",
+		edgesInfo += fmt.Sprintf("Key: %d, Source: %d -> Destination: %d, Weight: %d, Label: %s",
 			key, edge.Source, edge.Destination, edge.Weight, edge.Label)
+		for _, name := range slices.Sorted(maps.Keys(edge.Attributes)) {
+			edgesInfo += fmt.Sprintf(", %s: %d", name, edge.Attributes[name])
+		}
+		edgesInfo += "\n"
 	}
 
 	cli.showScrollableModal("Edges List", edgesInfo, "edge_operations")
 }
+
+func parseEdgeAttributes(text string) ([]graph.Option[graph.Edge], error) {
+	options := []graph.Option[graph.Edge]{}
+	for _, pair := range strings.Split(text, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, valueStr, found := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid attribute %q, expected name=value", pair)
+		}
+
+		value, err := strconv.ParseInt(strings.TrimSpace(valueStr), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of attribute %q", name)
+		}
+		options = append(options, graph.WithEdgeAttribute(name, graph.TWeight(value)))
+	}
+	return options, nil
+}
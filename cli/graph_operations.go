@@ -16,6 +16,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/tolstovrob/graph-go/algo"
 	"github.com/tolstovrob/graph-go/graph"
 )
 
@@ -49,15 +50,19 @@ func (cli *CLIService) showGraphInfo() {
 func (cli *CLIService) showJSONOperations() {
 	modal := tview.NewModal().
 		SetText("JSON Operations").
-		AddButtons([]string{"Save to JSON", "Load from JSON", "Show JSON", "Back"}).
+		AddButtons([]string{"Save to JSON", "Load from JSON", "Load Pattern", "Show JSON", "Export to DOT", "Back"}).
 		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
 			switch buttonLabel {
 			case "Save to JSON":
 				cli.showSaveJSONForm()
 			case "Load from JSON":
 				cli.showLoadJSONForm()
+			case "Load Pattern":
+				cli.showLoadPatternForm()
 			case "Show JSON":
 				cli.showJSONView()
+			case "Export to DOT":
+				cli.showExportDOTForm()
 			case "Back":
 				cli.pages.SwitchToPage("main")
 			}
@@ -90,6 +95,17 @@ func (cli *CLIService) getDetailedGraphInfo() string {
 	info.WriteString(fmt.Sprintf("Total Nodes: %d\n", len(cli.graph.Nodes)))
 	info.WriteString(fmt.Sprintf("Total Edges: %d\n\n", len(cli.graph.Edges)))
 
+	triangles, trianglesErr := algo.CountTriangles(cli.graph)
+	cores, coresErr := algo.FindCoreDecomposition(cli.graph)
+	if trianglesErr == nil && coresErr == nil {
+		info.WriteString("CLUSTERING AND CORES\n")
+		info.WriteString(strings.Repeat("─", 50) + "\n")
+		info.WriteString(fmt.Sprintf("Triangles: %d\n", triangles.Triangles))
+		info.WriteString(fmt.Sprintf("Average clustering: %.4f\n", triangles.AverageClustering))
+		info.WriteString(fmt.Sprintf("Transitivity: %.4f\n", triangles.Transitivity))
+		info.WriteString(fmt.Sprintf("Degeneracy: %d\n\n", cores.Degeneracy))
+	}
+
 	info.WriteString("NODES LIST\n")
 	info.WriteString(strings.Repeat("─", 50) + "\n")
 	if len(cli.graph.Nodes) == 0 {
@@ -104,8 +120,13 @@ func (cli *CLIService) getDetailedGraphInfo() string {
 		for _, key := range keys {
 			node := cli.graph.Nodes[key]
 			degree := len(cli.graph.AdjacencyMap[key])
-			info.WriteString(fmt.Sprintf("Key: %4d | Label: %-20s | Degree: %d\n",
+			info.WriteString(fmt.Sprintf("Key: %4d | Label: %-20s | Degree: %d",
 				key, node.Label, degree))
+			if trianglesErr == nil && coresErr == nil {
+				info.WriteString(fmt.Sprintf(" | Triangles: %d | Clustering: %.3f | Core: %d",
+					triangles.NodeTriangles[key], triangles.LocalClustering[key], cores.CoreNumber[key]))
+			}
+			info.WriteString("\n")
 		}
 	}
 	info.WriteString("\n")
@@ -208,15 +229,8 @@ func (cli *CLIService) showLoadJSONForm() {
 			return
 		}
 
-		data, err := os.ReadFile(filename)
+		newGraph, err := cli.readGraphFile(filename)
 		if err != nil {
-			cli.updateStatus(fmt.Sprintf("Error reading file: %v", err), Error)
-			return
-		}
-
-		newGraph := graph.MakeGraph()
-		if err := newGraph.FromJSON(string(data)); err != nil {
-			cli.updateStatus(fmt.Sprintf("Error parsing JSON: %v", err), Error)
 			return
 		}
 
@@ -232,6 +246,81 @@ func (cli *CLIService) showLoadJSONForm() {
 	cli.pages.AddAndSwitchToPage("load_json", form, true)
 }
 
+func (cli *CLIService) showLoadPatternForm() {
+	form := tview.NewForm()
+	var filename string
+
+	form.AddInputField("Filename", "examples/tasks/", 30, nil, func(text string) {
+		filename = text
+	})
+	form.AddButton("Load", func() {
+		if filename == "" {
+			cli.updateStatus("Error: Filename cannot be empty", Error)
+			return
+		}
+
+		pattern, err := cli.readGraphFile(filename)
+		if err != nil {
+			return
+		}
+
+		cli.pattern = pattern
+		cli.updateStatus(fmt.Sprintf("Pattern loaded from %s: %d nodes, %d edges", filename, len(pattern.Nodes), len(pattern.Edges)), Success)
+		cli.pages.SwitchToPage("main")
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("json_operations")
+	})
+
+	form.SetBorder(true).SetTitle(" Load Pattern Graph from JSON ")
+	cli.pages.AddAndSwitchToPage("load_pattern", form, true)
+}
+
+func (cli *CLIService) readGraphFile(filename string) (*graph.Graph, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		cli.updateStatus(fmt.Sprintf("Error reading file: %v", err), Error)
+		return nil, err
+	}
+
+	newGraph := graph.MakeGraph()
+	if err := newGraph.FromJSON(string(data)); err != nil {
+		cli.updateStatus(fmt.Sprintf("Error parsing JSON: %v", err), Error)
+		return nil, err
+	}
+	return newGraph, nil
+}
+
+func (cli *CLIService) showExportDOTForm() {
+	form := tview.NewForm()
+	var filename string
+
+	form.AddInputField("Filename", "graph.dot", 30, nil, func(text string) {
+		filename = text
+	})
+	form.AddButton("Export", func() {
+		if filename == "" {
+			cli.updateStatus("Error: Filename cannot be empty", Error)
+			return
+		}
+
+		err := os.WriteFile(filename, []byte(cli.graph.ToDOT()), 0644)
+		if err != nil {
+			cli.updateStatus(fmt.Sprintf("Error writing file: %v", err), Error)
+			return
+		}
+
+		cli.updateStatus(fmt.Sprintf("Graph exported to %s successfully", filename), Success)
+		cli.pages.SwitchToPage("main")
+	})
+	form.AddButton("Cancel", func() {
+		cli.pages.SwitchToPage("json_operations")
+	})
+
+	form.SetBorder(true).SetTitle(" Export Graph to DOT ")
+	cli.pages.AddAndSwitchToPage("export_dot", form, true)
+}
+
 func (cli *CLIService) showJSONView() {
 	jsonData, err := cli.graph.ToJSON()
 	if err != nil {
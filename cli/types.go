@@ -9,20 +9,27 @@ package cli
 
 import (
 	"github.com/rivo/tview"
+	"github.com/tolstovrob/graph-go/algo"
 	"github.com/tolstovrob/graph-go/graph"
 )
 
 /*
  * CLI struct represents application state and configuration. It has graph
- * field, which contains info about worked graph. Also it has app fields for
- * configuration of TUI
+ * field, which contains info about worked graph, and pattern field with second
+ * graph for isomorphism and subgraph matching. Hierarchy field keeps contraction
+ * hierarchy index for fast shortest path queries and reachability field keeps
+ * index for reachability queries, if they were built. Also it has app fields
+ * for configuration of TUI
  */
 
 type CLIService struct {
-	app        *tview.Application
-	pages      *tview.Pages
-	statusView *tview.TextView
-	graph      *graph.Graph
+	app          *tview.Application
+	pages        *tview.Pages
+	statusView   *tview.TextView
+	graph        *graph.Graph
+	pattern      *graph.Graph
+	hierarchy    *algo.ContractionHierarchy
+	reachability *algo.ReachabilityIndex
 }
 
 /*
@@ -0,0 +1,113 @@
+/*
+ * This is a graph package, which contains graoh definition and basic operations
+ * on it. As you go through the file, you will see some comments, that are
+ * explaining this or that choice, etc.
+ *
+ * Author: github.com/tolstovrob
+ */
+
+package graph
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+/*
+ * DOT export.
+ *
+ * Graphviz DOT is a plain text format, which can be rendered with `dot -Tpng`
+ * or any online viewer. Graph is exported as `graph` or `digraph` depending on
+ * IsDirected, nodes and edges are written in order of their keys, so the same
+ * graph always gives the same file.
+ *
+ * Colorings found by algorithms can be rendered too: they are just numbers of
+ * colors per node key or per edge key, passed with options:
+ *
+ * dot := gr.ToDOT(WithDOTNodeColors(coloring.Colors))
+ *
+ * First colors are taken from a fixed palette, the rest are spread over hue.
+ */
+
+type DOTOptions struct {
+	NodeColors map[TKey]int // Color number of every node, nodes without one are not filled
+	EdgeColors map[TKey]int // Color number of every edge
+}
+
+func WithDOTNodeColors(colors map[TKey]int) Option[DOTOptions] {
+	return func(opts *DOTOptions) {
+		opts.NodeColors = colors
+	}
+}
+
+func WithDOTEdgeColors(colors map[TKey]int) Option[DOTOptions] {
+	return func(opts *DOTOptions) {
+		opts.EdgeColors = colors
+	}
+}
+
+var dotPalette = []string{
+	"#e6194b", "#3cb44b", "#ffe119", "#4363d8", "#f58231", "#911eb4",
+	"#46f0f0", "#f032e6", "#bcf60c", "#fabebe", "#008080", "#e6beff",
+}
+
+func DOTColor(color int) string {
+	if color >= 0 && color < len(dotPalette) {
+		return dotPalette[color]
+	}
+	// Golden ratio steps give hues far from each other
+	hue := float64(color) * 0.618033988749895
+	hue -= float64(int(hue))
+	return fmt.Sprintf("%.3f 0.650 0.950", hue)
+}
+
+func (gr *Graph) ToDOT(options ...Option[DOTOptions]) string {
+	opts := DOTOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	kind, arrow := "graph", "--"
+	if gr.Options.IsDirected {
+		kind, arrow = "digraph", "->"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s G {\n", kind))
+
+	nodeKeys := slices.Sorted(maps.Keys(gr.Nodes))
+	for _, key := range nodeKeys {
+		label := fmt.Sprintf("%d", key)
+		if gr.Nodes[key].Label != "" {
+			label += "\\n" + escapeDOT(gr.Nodes[key].Label)
+		}
+		sb.WriteString(fmt.Sprintf("  %d [label=\"%s\"", key, label))
+		if color, ok := opts.NodeColors[key]; ok {
+			sb.WriteString(fmt.Sprintf(", style=filled, fillcolor=\"%s\"", DOTColor(color)))
+		}
+		sb.WriteString("];\n")
+	}
+
+	edgeKeys := slices.Sorted(maps.Keys(gr.Edges))
+	for _, key := range edgeKeys {
+		edge := gr.Edges[key]
+		label := fmt.Sprintf("%d", edge.Weight)
+		if edge.Label != "" {
+			label += " " + escapeDOT(edge.Label)
+		}
+		sb.WriteString(fmt.Sprintf("  %d %s %d [label=\"%s\"", edge.Source, arrow, edge.Destination, label))
+		if color, ok := opts.EdgeColors[key]; ok {
+			sb.WriteString(fmt.Sprintf(", color=\"%s\", penwidth=2", DOTColor(color)))
+		}
+		sb.WriteString("];\n")
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func escapeDOT(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text)
+}
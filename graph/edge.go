@@ -28,14 +28,20 @@ package graph
  * or with optional fields:
  *
  * fullyConstructedEdge := MakeEdge(1, src.Key, dst.Key, WithEdgeLabel("Path"), WithEdgeWeight(69))
+ *
+ * One weight is not always enough: flow network needs both capacity and cost
+ * of an edge. Such extra numbers go to named attributes:
+ *
+ * road := MakeEdge(2, src.Key, dst.Key, WithEdgeAttribute("capacity", 10), WithEdgeAttribute("cost", 3))
  */
 
 type Edge struct {
-	Key         TKey    `json:"key"`
-	Source      TKey    `json:"source"`
-	Destination TKey    `json:"destination"`
-	Weight      TWeight `json:"weight"`
-	Label       string  `json:"label"`
+	Key         TKey               `json:"key"`
+	Source      TKey               `json:"source"`
+	Destination TKey               `json:"destination"`
+	Weight      TWeight            `json:"weight"`
+	Label       string             `json:"label"`
+	Attributes  map[string]TWeight `json:"attributes,omitempty"`
 }
 
 func MakeEdge(key, src, dst TKey, options ...Option[Edge]) *Edge {
@@ -64,3 +70,17 @@ func WithEdgeLabel(label string) Option[Edge] {
 		edge.Label = label
 	}
 }
+
+func WithEdgeAttribute(name string, value TWeight) Option[Edge] {
+	return func(edge *Edge) {
+		if edge.Attributes == nil {
+			edge.Attributes = make(map[string]TWeight)
+		}
+		edge.Attributes[name] = value
+	}
+}
+
+func (edge *Edge) GetAttribute(name string) (TWeight, bool) {
+	value, ok := edge.Attributes[name]
+	return value, ok
+}
@@ -41,3 +41,7 @@ func ThrowGraphUnmarshalError() error {
 func ThrowGraphNotDirected() error {
 	return fmt.Errorf("Graph is not directed, but have to be")
 }
+
+func ThrowGraphDirected() error {
+	return fmt.Errorf("Graph is directed, but have to be undirected")
+}
@@ -11,6 +11,7 @@ package graph
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"slices"
 )
 
@@ -50,6 +51,7 @@ type Graph struct {
 	Edges        map[TKey]*Edge  `json:"edges"`
 	AdjacencyMap map[TKey][]TKey `json:"adjacencyMap"`
 	Options      TOptions        `json:"options"`
+	revision     uint64          // Bumped on every change, see revision.go
 }
 
 func MakeGraph(options ...Option[Graph]) *Graph {
@@ -83,6 +85,7 @@ func (gr *Graph) Copy() *Graph {
 			Destination: edge.Destination,
 			Weight:      edge.Weight,
 			Label:       edge.Label,
+			Attributes:  maps.Clone(edge.Attributes),
 		}
 	}
 
@@ -138,14 +141,17 @@ func (gr *Graph) RebuildEdges() {
 			Destination: edge.Destination,
 			Weight:      edge.Weight,
 			Label:       edge.Label,
+			Attributes:  maps.Clone(edge.Attributes),
 		}
 		newEdges[key] = newEdge
 	}
 
 	gr.Edges = newEdges
+	gr.revision++
 }
 
 func (gr *Graph) RebuildAdjacencyMap() {
+	gr.revision++
 	gr.AdjacencyMap = make(map[TKey][]TKey)
 	for _, edge := range gr.Edges {
 		gr.AdjacencyMap[edge.Source] = append(gr.AdjacencyMap[edge.Source], edge.Destination)
@@ -169,6 +175,7 @@ func (gr *Graph) UpdateGraph(options ...Option[Graph]) {
 	for _, opt := range options {
 		opt(gr)
 	}
+	gr.revision++ // Options may replace nodes, edges or adjacency map
 
 	if oldOptions != gr.Options {
 		gr.RebuildEdges()
@@ -236,6 +243,7 @@ func (gr *Graph) AddNode(node *Node) error {
 	}
 
 	gr.Nodes[node.Key] = node
+	gr.revision++
 	return nil
 }
 
@@ -259,6 +267,7 @@ func (gr *Graph) RemoveNodeByKey(key TKey) error {
 	// Remove the node
 	delete(gr.Nodes, key)
 	delete(gr.AdjacencyMap, key)
+	gr.revision++
 
 	// Remove references to this node from other nodes' adjacency lists
 	for nodeKey, neighbors := range gr.AdjacencyMap {
@@ -0,0 +1,74 @@
+/*
+ * This is a graph package, which contains graoh definition and basic operations
+ * on it. As you go through the file, you will see some comments, that are
+ * explaining this or that choice, etc.
+ *
+ * Author: github.com/tolstovrob
+ */
+
+package graph
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"maps"
+	"slices"
+)
+
+/*
+ * Revision and fingerprint.
+ *
+ * Some algorithms build indexes over graph (I.e. contraction hierarchies for
+ * fast shortest path queries), which become wrong as soon as graph changes.
+ * To notice it cheaply, graph has a revision counter, which is bumped by every
+ * method that changes nodes or edges: AddNode, RemoveNodeByKey, AddEdge,
+ * RemoveEdgeByKey, UpdateGraph, RebuildEdges and RebuildAdjacencyMap.
+ *
+ * Edges and nodes are pointers, so they can be changed directly, I.e. with
+ * edge.UpdateEdge(WithEdgeWeight(5)). Graph cannot see it, so call Touch after
+ * such changes:
+ *
+ * edge.UpdateEdge(WithEdgeWeight(5))
+ * gr.Touch()
+ *
+ * Revision lives only in memory. To check that an index saved to disk belongs
+ * to a graph, use Fingerprint: a hash of options, node keys and edges (keys,
+ * ends and weights). Labels and attributes are not included. It is O(E log E),
+ * so do not call it on every query.
+ */
+
+func (gr *Graph) Revision() uint64 {
+	return gr.revision
+}
+
+func (gr *Graph) Touch() {
+	gr.revision++
+}
+
+func (gr *Graph) Fingerprint() uint64 {
+	hash := fnv.New64a()
+	write := func(values ...uint64) {
+		for _, value := range values {
+			hash.Write(binary.LittleEndian.AppendUint64(nil, value))
+		}
+	}
+
+	flags := uint64(0)
+	if gr.Options.IsDirected {
+		flags |= 1
+	}
+	if gr.Options.IsMulti {
+		flags |= 2
+	}
+	write(flags, uint64(len(gr.Nodes)), uint64(len(gr.Edges)))
+
+	for _, key := range slices.Sorted(maps.Keys(gr.Nodes)) {
+		write(uint64(key))
+	}
+	for _, key := range slices.Sorted(maps.Keys(gr.Edges)) {
+		edge := gr.Edges[key]
+		write(uint64(key), uint64(edge.Source), uint64(edge.Destination), uint64(edge.Weight))
+	}
+
+	return hash.Sum64()
+}
@@ -0,0 +1,1029 @@
+package graph_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tolstovrob/graph-go/algo"
+	"github.com/tolstovrob/graph-go/graph"
+)
+
+// makeTestGraph builds graph with nodes 1..n and edges {src, dst, weight} keyed 1..len(edges)
+func makeTestGraph(directed, multi bool, n int, edges [][3]int64) *graph.Graph {
+	gr := graph.MakeGraph(graph.WithGraphDirected(directed), graph.WithGraphMulti(multi))
+	for i := 1; i <= n; i++ {
+		gr.AddNode(graph.MakeNode(graph.TKey(i)))
+	}
+	for i, e := range edges {
+		gr.AddEdge(graph.MakeEdge(graph.TKey(i+1), graph.TKey(e[0]), graph.TKey(e[1]), graph.WithEdgeWeight(graph.TWeight(e[2]))))
+	}
+	return gr
+}
+
+func TestFindBiconnectivity(t *testing.T) {
+	// Two triangles 1-2-3 and 3-4-5 joined at 3, plus pendant edge 5-6
+	gr := makeTestGraph(false, false, 6, [][3]int64{
+		{1, 2, 1}, {2, 3, 1}, {3, 1, 1}, {3, 4, 1}, {4, 5, 1}, {5, 3, 1}, {5, 6, 1},
+	})
+
+	result, err := algo.FindBiconnectivity(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(result.ArticulationPoints, []graph.TKey{3, 5}) {
+		t.Errorf("Expected articulation points [3 5], got %v", result.ArticulationPoints)
+	}
+	if !slices.Equal(result.Bridges, []graph.TKey{7}) {
+		t.Errorf("Expected bridges [7], got %v", result.Bridges)
+	}
+	if len(result.BiconnectedComponents) != 3 {
+		t.Errorf("Expected 3 blocks, got %d", len(result.BiconnectedComponents))
+	}
+	if len(result.TwoEdgeConnectedComponents) != 2 {
+		t.Errorf("Expected 2 two-edge-connected components, got %d", len(result.TwoEdgeConnectedComponents))
+	}
+}
+
+func TestParallelEdgeIsNotBridge(t *testing.T) {
+	gr := makeTestGraph(false, true, 3, [][3]int64{{1, 2, 1}, {1, 2, 1}, {2, 3, 1}})
+
+	result, _ := algo.FindBiconnectivity(gr)
+	if !slices.Equal(result.Bridges, []graph.TKey{3}) {
+		t.Errorf("Expected only edge 3 to be a bridge, got %v", result.Bridges)
+	}
+}
+
+func TestCanRemoveVertexToMakeTree(t *testing.T) {
+	// Square 1-2-3-4 with a chord 1-3 and a pendant 5 hanging on 1: only dropping 3 leaves a star
+	gr := makeTestGraph(false, false, 5, [][3]int64{
+		{1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {4, 1, 1}, {1, 3, 1}, {1, 5, 1},
+	})
+
+	ok, candidates, err := algo.CanRemoveVertexToMakeTree(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ok || !slices.Equal(candidates, []graph.TKey{3}) {
+		t.Errorf("Expected candidates [3], got %v", candidates)
+	}
+
+	// Triangle 1-2-3 with a tail 3-4: dropping 1 or 2 leaves a path
+	gr = makeTestGraph(false, false, 4, [][3]int64{{1, 2, 1}, {2, 3, 1}, {3, 1, 1}, {3, 4, 1}})
+	_, candidates, _ = algo.CanRemoveVertexToMakeTree(gr)
+	if !slices.Equal(candidates, []graph.TKey{1, 2}) {
+		t.Errorf("Expected candidates [1 2], got %v", candidates)
+	}
+}
+
+func TestFindEulerianTrail(t *testing.T) {
+	// Directed multigraph: two parallel edges 1→2 and way back through 3
+	gr := makeTestGraph(true, true, 3, [][3]int64{{1, 2, 1}, {1, 2, 1}, {2, 1, 1}, {2, 3, 1}, {3, 1, 1}})
+
+	result, err := algo.FindEulerianTrail(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.HasCircuit || len(result.Edges) != 5 {
+		t.Fatalf("Expected Eulerian circuit through 5 edges, got %+v", result)
+	}
+	for i, key := range result.Edges {
+		edge := gr.Edges[key]
+		if edge.Source != result.Vertices[i] || edge.Destination != result.Vertices[i+1] {
+			t.Errorf("Edge %d does not connect %d → %d", key, result.Vertices[i], result.Vertices[i+1])
+		}
+	}
+
+	gr.RemoveEdgeByKey(3)
+	gr.RemoveEdgeByKey(4)
+	result, _ = algo.FindEulerianTrail(gr)
+	if result.HasPath || len(result.Reasons) == 0 {
+		t.Errorf("Expected no trail with reasons, got %+v", result)
+	}
+}
+
+func TestFindChinesePostmanRoute(t *testing.T) {
+	// Path 1-2-3 with weights 2 and 3: both edges are walked twice
+	gr := makeTestGraph(false, false, 3, [][3]int64{{1, 2, 2}, {2, 3, 3}})
+
+	result, err := algo.FindChinesePostmanRoute(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.TotalWeight != 10 || len(result.Edges) != 4 {
+		t.Errorf("Expected route of weight 10 through 4 edges, got %d through %d", result.TotalWeight, len(result.Edges))
+	}
+	if result.Vertices[0] != result.Vertices[len(result.Vertices)-1] {
+		t.Errorf("Expected closed route, got %v", result.Vertices)
+	}
+}
+
+func TestDisjointSet(t *testing.T) {
+	ds := algo.MakeDisjointSet(1, 2, 3, 4)
+	if !ds.Union(1, 2) || !ds.Union(3, 4) || ds.Union(2, 1) {
+		t.Errorf("Unexpected union results")
+	}
+	if !ds.Connected(1, 2) || ds.Connected(1, 3) || ds.Count() != 2 {
+		t.Errorf("Expected 2 sets {1,2} and {3,4}, got %d sets", ds.Count())
+	}
+}
+
+func TestMSTAlgorithmsAgree(t *testing.T) {
+	gr := makeTestGraph(false, true, 5, [][3]int64{
+		{1, 2, 4}, {1, 2, 1}, {2, 3, 2}, {3, 1, 2}, {3, 4, 7}, {4, 5, 3}, {2, 5, 5},
+	})
+
+	comparison, err := algo.CompareMSTAlgorithms(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !comparison.Agree || comparison.Results[0].TotalWeight != 11 {
+		t.Errorf("Expected all algorithms to agree on weight 11, got %s", comparison.FormatMSTComparison())
+	}
+
+	maximum, _ := algo.FindMSTBoruvka(gr, algo.WithMSTMaximum(true))
+	if maximum.TotalWeight != 19 {
+		t.Errorf("Expected maximum spanning tree weight 19, got %d", maximum.TotalWeight)
+	}
+
+	// Island 6 makes graph disconnected: tree is impossible, forest is not
+	gr.AddNode(graph.MakeNode(6))
+	tree, _ := algo.FindMSTKruskal(gr)
+	forest, _ := algo.FindMinimumSpanningForest(gr)
+	if tree.IsPossible || !forest.IsPossible || forest.Components != 2 || forest.TotalWeight != 11 {
+		t.Errorf("Expected impossible tree and forest of 2 trees with weight 11")
+	}
+}
+
+func TestFindMinArborescence(t *testing.T) {
+	// Cheapest incoming edges 2→3 and 3→2 form a cycle that has to be broken
+	gr := makeTestGraph(true, false, 3, [][3]int64{{1, 2, 10}, {1, 3, 12}, {2, 3, 1}, {3, 2, 2}})
+
+	result, err := algo.FindMinArborescence(gr, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.TotalWeight != 11 || len(result.Edges) != 2 {
+		t.Errorf("Expected arborescence of weight 11 with 2 edges, got %d with %d", result.TotalWeight, len(result.Edges))
+	}
+
+	if _, err := algo.FindMinArborescence(gr, 2); err == nil {
+		t.Errorf("Expected error for root 2, node 1 is unreachable")
+	}
+}
+
+func TestJohnsonMatchesFloyd(t *testing.T) {
+	// Negative edge 2→3 without negative cycles
+	gr := makeTestGraph(true, false, 4, [][3]int64{{1, 2, 4}, {1, 3, 5}, {2, 3, -3}, {3, 4, 2}, {4, 1, 1}})
+
+	floyd, _ := algo.FindAllPairsShortestPathFloyd(gr)
+	johnson, err := algo.FindAllPairsShortestPathJohnson(gr)
+	if err != nil || !johnson.IsValid {
+		t.Fatalf("Expected valid result, got %v", err)
+	}
+	for from := range gr.Nodes {
+		for to := range gr.Nodes {
+			expected, _ := floyd.Distance(from, to)
+			if actual, _ := johnson.Distance(from, to); actual != expected {
+				t.Errorf("Distance %d → %d: Floyd %d, Johnson %d", from, to, expected, actual)
+			}
+		}
+	}
+	if path := johnson.GetPath(1, 4); !slices.Equal(path, []graph.TKey{1, 2, 3, 4}) {
+		t.Errorf("Expected path [1 2 3 4], got %v", path)
+	}
+
+	// Floyd-Warshall matrix is dense with 1 << 30 for unreachable pairs, Johnson's keeps only reachable ones
+	far := makeTestGraph(true, false, 3, [][3]int64{{1, 2, 1 << 31}, {2, 3, 1 << 31}})
+	floyd, _ = algo.FindAllPairsShortestPathFloyd(far)
+	if dist, exists := floyd.Distances[3][1]; !exists || dist != 1<<30 {
+		t.Errorf("Floyd-Warshall: expected unreachable pair stored as 2^30, got %d (exists %v)", dist, exists)
+	}
+	johnson, _ = algo.FindAllPairsShortestPathJohnson(far)
+	if _, exists := johnson.Distances[3][1]; exists {
+		t.Error("Johnson: expected unreachable pair to be absent")
+	}
+	// Distances above 2^30 are real distances in Johnson's result
+	if dist, reachable := johnson.Distance(1, 3); !reachable || dist != 1<<32 {
+		t.Errorf("Johnson: expected distance 2^32 from 1 to 3, got %d (reachable %v)", dist, reachable)
+	}
+	if _, reachable := johnson.Distance(3, 1); reachable {
+		t.Error("Johnson: expected node 1 unreachable from 3")
+	}
+
+	gr.AddEdge(graph.MakeEdge(6, 3, 2, graph.WithEdgeWeight(1)))
+	if result, _ := algo.FindAllPairsShortestPathJohnson(gr); result.IsValid {
+		t.Errorf("Expected negative cycle 2 → 3 → 2 to be detected")
+	}
+}
+
+func TestBellmanFordAndSPFA(t *testing.T) {
+	gr := makeTestGraph(true, false, 4, [][3]int64{{1, 2, 4}, {1, 3, 5}, {2, 3, -3}, {3, 4, 2}})
+
+	for _, run := range []func(*graph.Graph, graph.TKey) (*algo.BellmanFordResult, error){algo.BellmanFord, algo.SPFA} {
+		result, err := run(gr, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.HasNegativeCycle || result.Distances[4] != 3 {
+			t.Errorf("%s: expected distance 3 to vertex 4, got %d", result.Algorithm, result.Distances[4])
+		}
+		if path := result.GetPath(4); !slices.Equal(path, []graph.TKey{1, 2, 3, 4}) {
+			t.Errorf("%s: expected path [1 2 3 4], got %v", result.Algorithm, path)
+		}
+	}
+
+	// Cycle 3 → 4 → 3 of weight -1 is reachable from 1
+	gr.AddEdge(graph.MakeEdge(5, 4, 3, graph.WithEdgeWeight(-3)))
+	result, _ := algo.SPFA(gr, 1)
+	if !result.HasNegativeCycle || result.NegativeCycle.TotalWeight != -1 {
+		t.Errorf("Expected negative cycle of weight -1, got %+v", result.NegativeCycle)
+	}
+
+	cycles, _ := algo.FindNegativeCyclesSuperSource(gr)
+	if cycles.TotalCycles != 1 || !slices.Equal(cycles.Cycles[0].Vertices, []graph.TKey{3, 4}) {
+		t.Errorf("Expected single negative cycle [3 4], got %+v", cycles.Cycles)
+	}
+}
+
+func TestMaxFlowAlgorithmsAgree(t *testing.T) {
+	// Two parallel edges 1 → 2 must add up to capacity 5
+	gr := makeTestGraph(true, true, 4, [][3]int64{{1, 2, 2}, {1, 2, 3}, {1, 3, 4}, {2, 4, 6}, {3, 4, 2}, {2, 3, 1}})
+
+	for _, algorithm := range algo.MaxFlowAlgorithms {
+		result, err := algo.FindMaxFlow(gr, 1, 4, algo.WithMaxFlowAlgorithm(algorithm))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.MaxFlowValue != 7 {
+			t.Errorf("%s: expected max flow 7, got %d", algorithm, result.MaxFlowValue)
+		}
+		if !slices.Equal(result.MinCut, []graph.TKey{1, 3}) && !slices.Equal(result.MinCut, []graph.TKey{1, 2, 3}) {
+			t.Errorf("%s: unexpected min cut %v", algorithm, result.MinCut)
+		}
+	}
+
+	if _, err := algo.FindMaxFlow(gr, 1, 4, algo.WithMaxFlowAlgorithm("Ford")); err == nil {
+		t.Error("Expected error for unknown algorithm")
+	}
+}
+
+func TestFindMinCostFlow(t *testing.T) {
+	gr := makeTestGraph(true, false, 4, nil)
+	roads := [][4]int64{{1, 2, 4, 1}, {1, 3, 2, 2}, {2, 3, 2, 1}, {2, 4, 3, 3}, {3, 4, 5, 1}}
+	for i, road := range roads {
+		gr.AddEdge(graph.MakeEdge(graph.TKey(i+1), graph.TKey(road[0]), graph.TKey(road[1]),
+			graph.WithEdgeAttribute("capacity", graph.TWeight(road[2])),
+			graph.WithEdgeAttribute("cost", graph.TWeight(road[3]))))
+	}
+
+	for _, scaling := range []bool{false, true} {
+		result, err := algo.FindMinCostFlow(gr, 1, 4, algo.WithCapacityField("capacity"), algo.WithCostScaling(scaling))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.FlowValue != 6 || result.TotalCost != 20 {
+			t.Errorf("%s: expected flow 6 with cost 20, got %d with cost %d", result.Algorithm, result.FlowValue, result.TotalCost)
+		}
+
+		result, _ = algo.FindMinCostFlow(gr, 1, 4, algo.WithCapacityField("capacity"), algo.WithTargetFlow(2), algo.WithCostScaling(scaling))
+		if !result.IsTargetReached || result.TotalCost != 6 {
+			t.Errorf("%s: expected target 2 reached with cost 6, got %+v", result.Algorithm, result)
+		}
+	}
+
+	if _, err := algo.FindMinCostFlow(gr, 1, 4, algo.WithCostField("price")); err == nil {
+		t.Error("Expected error for missing cost attribute")
+	}
+}
+
+func TestMaxFlowCutAndTerminals(t *testing.T) {
+	// Sources 1 and 2 feed hub 3, which forwards to sinks 4 and 5
+	gr := makeTestGraph(true, false, 5, [][3]int64{{1, 3, 4}, {2, 3, 5}, {3, 4, 3}, {3, 5, 7}})
+
+	result, err := algo.FindMaxFlow(gr, 1, 4, algo.WithMaxFlowSources(2), algo.WithMaxFlowSinks(5))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.MaxFlowValue != 9 {
+		t.Errorf("Expected flow 9, got %d", result.MaxFlowValue)
+	}
+	cutKeys := []graph.TKey{}
+	for _, edge := range result.CutEdges {
+		cutKeys = append(cutKeys, edge.EdgeKey)
+	}
+	if !slices.Equal(cutKeys, []graph.TKey{1, 2}) {
+		t.Errorf("Expected cut edges [1 2], got %v", cutKeys)
+	}
+
+	// Hub capacity 6 becomes the bottleneck
+	result, _ = algo.FindMaxFlow(gr, 1, 4, algo.WithMaxFlowSources(2), algo.WithMaxFlowSinks(5),
+		algo.WithNodeCapacities(map[graph.TKey]graph.TWeight{3: 6}))
+	if result.MaxFlowValue != 6 || !slices.Equal(result.CutNodes, []graph.TKey{3}) || len(result.CutEdges) != 0 {
+		t.Errorf("Expected flow 6 cut at node 3, got %d, nodes %v, edges %v",
+			result.MaxFlowValue, result.CutNodes, result.CutEdges)
+	}
+}
+
+func TestDecomposeFlow(t *testing.T) {
+	// Flow 1 → 2 → 3 → 4 with extra loop 2 → 3 → 2
+	flowEdges := []algo.FlowEdge{
+		{EdgeKey: 1, Source: 1, Destination: 2, Flow: 3},
+		{EdgeKey: 2, Source: 2, Destination: 3, Flow: 5},
+		{EdgeKey: 3, Source: 3, Destination: 2, Flow: 2},
+		{EdgeKey: 4, Source: 3, Destination: 4, Flow: 3},
+	}
+
+	decomposition := algo.DecomposeFlow(flowEdges, []graph.TKey{1}, []graph.TKey{4})
+	if len(decomposition.Paths) != 1 || decomposition.Paths[0].Amount != 3 ||
+		!slices.Equal(decomposition.Paths[0].Edges, []graph.TKey{1, 2, 4}) {
+		t.Errorf("Expected single path 1-2-4 of amount 3, got %+v", decomposition.Paths)
+	}
+	if len(decomposition.Cycles) != 1 || decomposition.Cycles[0].Amount != 2 {
+		t.Errorf("Expected single cycle of amount 2, got %+v", decomposition.Cycles)
+	}
+
+	gr := makeTestGraph(true, false, 4, [][3]int64{{1, 2, 2}, {1, 3, 2}, {2, 4, 2}, {3, 4, 2}})
+	result, _ := algo.FindMaxFlow(gr, 1, 4)
+	if len(result.Decomposition.Paths) != 2 {
+		t.Errorf("Expected two flow paths, got %+v", result.Decomposition.Paths)
+	}
+}
+
+func TestGlobalMinCutAndGomoryHu(t *testing.T) {
+	// Two triangles joined by a light bridge 3 - 4
+	gr := makeTestGraph(false, false, 6, [][3]int64{
+		{1, 2, 5}, {2, 3, 5}, {1, 3, 5}, {3, 4, 2}, {4, 5, 4}, {5, 6, 4}, {4, 6, 4},
+	})
+
+	cut, err := algo.FindGlobalMinCut(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cut.Value != 2 || !slices.Equal(cut.CutEdges, []graph.TKey{4}) {
+		t.Errorf("Expected cut of weight 2 through edge 4, got %d through %v", cut.Value, cut.CutEdges)
+	}
+
+	tree, err := algo.FindGomoryHuTree(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tree.Edges) != 5 {
+		t.Errorf("Expected tree with 5 edges, got %d", len(tree.Edges))
+	}
+	for _, pair := range [][3]int64{{1, 6, 2}, {1, 2, 10}, {4, 5, 8}} {
+		value, _ := algo.GomoryHuMinCut(tree, graph.TKey(pair[0]), graph.TKey(pair[1]))
+		if value != graph.TWeight(pair[2]) {
+			t.Errorf("Expected min cut %d between %d and %d, got %d", pair[2], pair[0], pair[1], value)
+		}
+	}
+}
+
+func TestConnectivityAndDisjointPaths(t *testing.T) {
+	// Two routes 1 → 4 through different middle nodes, sharing nothing but ends
+	gr := makeTestGraph(false, false, 5, [][3]int64{{1, 2, 1}, {2, 4, 1}, {1, 3, 1}, {3, 4, 1}, {4, 5, 1}, {3, 5, 1}})
+
+	result, err := algo.FindConnectivity(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.EdgeConnectivity != 2 || result.VertexConnectivity != 2 {
+		t.Errorf("Expected λ = 2 and κ = 2, got %d and %d", result.EdgeConnectivity, result.VertexConnectivity)
+	}
+
+	paths, err := algo.FindDisjointPaths(gr, 1, 5, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(paths.Paths) != 2 || len(paths.Separator) != 2 {
+		t.Errorf("Expected 2 vertex-disjoint paths and separator of 2, got %d and %v", len(paths.Paths), paths.Separator)
+	}
+
+	// Pendant node drops both numbers to 1
+	gr.AddNode(graph.MakeNode(6))
+	gr.AddEdge(graph.MakeEdge(7, 5, 6))
+	result, _ = algo.FindConnectivity(gr)
+	if result.EdgeConnectivity != 1 || result.VertexConnectivity != 1 {
+		t.Errorf("Expected λ = 1 and κ = 1, got %d and %d", result.EdgeConnectivity, result.VertexConnectivity)
+	}
+}
+
+func TestBipartiteMatching(t *testing.T) {
+	// Left 1, 2, 3 and right 4, 5, 6: node 3 only likes 4, so 1 and 2 have to share 5 and 6
+	gr := makeTestGraph(false, false, 6, [][3]int64{{1, 4, 1}, {1, 5, 1}, {2, 4, 1}, {2, 6, 1}, {3, 4, 1}})
+
+	bipartite, err := algo.IsBipartite(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bipartite.IsBipartite {
+		t.Fatalf("Expected bipartite graph, got odd cycle %v", bipartite.OddCycle)
+	}
+
+	matching, err := algo.FindBipartiteMatching(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if matching.Size != 3 || len(matching.VertexCover) != 3 {
+		t.Errorf("Expected matching and cover of size 3, got %d and %v", matching.Size, matching.VertexCover)
+	}
+	if matching.Mate[3] != 4 {
+		t.Errorf("Expected node 3 to be matched with 4, got %d", matching.Mate[3])
+	}
+
+	// Edge 4-5 closes triangle 1-4-5
+	gr.AddEdge(graph.MakeEdge(6, 4, 5))
+	gr.AddEdge(graph.MakeEdge(7, 1, 2))
+	bipartite, _ = algo.IsBipartite(gr)
+	if bipartite.IsBipartite || len(bipartite.OddCycleEdges)%2 != 1 {
+		t.Errorf("Expected odd cycle, got %v", bipartite.OddCycleEdges)
+	}
+	if _, err := algo.FindBipartiteMatching(gr); err == nil {
+		t.Error("Expected error for non-bipartite graph")
+	}
+}
+
+func TestAssignmentAndBlossom(t *testing.T) {
+	// Workers 1, 2 and jobs 3, 4: cheapest is 1-4 and 2-3, heaviest is 1-3 and 2-4
+	gr := makeTestGraph(false, false, 4, [][3]int64{{1, 3, 4}, {1, 4, 1}, {2, 3, 2}, {2, 4, 8}})
+
+	cheapest, err := algo.FindAssignment(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cheapest.IsPerfect || cheapest.TotalWeight != 3 || cheapest.Mate[1] != 4 {
+		t.Errorf("Expected perfect assignment of weight 3 with 1-4, got %d and %v", cheapest.TotalWeight, cheapest.Mate)
+	}
+
+	heaviest, _ := algo.FindAssignment(gr, algo.WithAssignmentMaximize(true))
+	if heaviest.TotalWeight != 12 || heaviest.Mate[2] != 4 {
+		t.Errorf("Expected assignment of weight 12 with 2-4, got %d and %v", heaviest.TotalWeight, heaviest.Mate)
+	}
+
+	// Two triangles joined by an edge: greedy may take the bridge, blossom finds 3 edges
+	gr = makeTestGraph(false, false, 6, [][3]int64{{1, 2, 1}, {2, 3, 1}, {3, 1, 1}, {3, 4, 1}, {4, 5, 1}, {5, 6, 1}, {6, 4, 1}})
+	matching, err := algo.FindMaximumMatching(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if matching.Size != 3 || len(matching.Mate) != 6 {
+		t.Errorf("Expected perfect matching of 3 edges, got %d", matching.Size)
+	}
+}
+
+func TestGraphColoring(t *testing.T) {
+	// 5-cycle needs 3 colors, wheel around it needs 4
+	gr := makeTestGraph(false, false, 6, [][3]int64{{1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {4, 5, 1}, {5, 1, 1}})
+
+	for _, order := range algo.ColoringOrders {
+		result, err := algo.GreedyColoring(gr, order)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, edge := range gr.Edges {
+			if result.Colors[edge.Source] == result.Colors[edge.Destination] {
+				t.Errorf("%s: edge %d joins nodes of the same color", order, edge.Key)
+			}
+		}
+	}
+
+	for key := int64(1); key <= 5; key++ {
+		gr.AddEdge(graph.MakeEdge(graph.TKey(5+key), graph.TKey(key), 6))
+	}
+	exact, err := algo.FindChromaticNumber(gr, time.Second)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !exact.IsExact || exact.NumColors != 4 {
+		t.Errorf("Expected chromatic number 4, got %d (exact %v)", exact.NumColors, exact.IsExact)
+	}
+
+	// Hub has degree 5, Misra-Gries needs at most 6 colors
+	edges, err := algo.FindEdgeColoring(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if edges.MaxDegree != 5 || edges.NumColors > 6 {
+		t.Errorf("Expected Δ = 5 and at most 6 colors, got %d and %d", edges.MaxDegree, edges.NumColors)
+	}
+
+	dot := gr.ToDOT(graph.WithDOTNodeColors(exact.Colors))
+	if !strings.HasPrefix(dot, "graph G {") || strings.Count(dot, "fillcolor") != 6 {
+		t.Errorf("Expected undirected DOT with 6 filled nodes, got:\n%s", dot)
+	}
+}
+
+func TestCliquesAndCovers(t *testing.T) {
+	// Two triangles sharing edge 2-3, plus pendant 4-5
+	gr := makeTestGraph(false, false, 5, [][3]int64{{1, 2, 1}, {1, 3, 1}, {2, 3, 1}, {2, 4, 1}, {3, 4, 1}, {4, 5, 1}})
+
+	cliques, err := algo.FindMaximalCliques(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cliques.Cliques) != 3 || len(cliques.MaximumClique) != 3 {
+		t.Errorf("Expected 3 maximal cliques, the largest of 3 nodes, got %v", cliques.Cliques)
+	}
+
+	independent, err := algo.FindMaximumIndependentSet(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(independent.Vertices) != 2 || !independent.IsExact {
+		t.Errorf("Expected exact independent set of 2 nodes, got %v", independent.Vertices)
+	}
+
+	cover, _ := algo.FindMinimumVertexCover(gr)
+	if len(cover.Vertices) != 3 || !cover.IsExact {
+		t.Errorf("Expected exact cover of 3 nodes, got %v", cover.Vertices)
+	}
+
+	approximate, _ := algo.FindMinimumVertexCover(gr, algo.WithExactLimit(0))
+	if approximate.IsExact || len(approximate.Vertices) > 6 {
+		t.Errorf("Expected approximate cover of at most 6 nodes, got %v", approximate.Vertices)
+	}
+}
+
+func TestCentralityMeasures(t *testing.T) {
+	// Path 1 - 2 - 3 - 4 with leaf 5 on node 2
+	gr := makeTestGraph(false, false, 5, [][3]int64{{1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {2, 5, 1}})
+
+	betweenness, err := algo.FindBetweenness(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Node 2 lies on paths 1-3, 1-4, 5-1, 5-3, 5-4 and node 3 on 1-4, 2-4, 5-4
+	if betweenness.Scores[2] != 5 || betweenness.Scores[3] != 3 || betweenness.Ranking[0] != 2 {
+		t.Errorf("Expected betweenness 5 and 3 for nodes 2 and 3, got %v", betweenness.Scores)
+	}
+
+	edges, _ := algo.FindEdgeBetweenness(gr)
+	if edges.Scores[1] != 4 || edges.Scores[2] != 6 {
+		t.Errorf("Expected edge betweenness 4 and 6 for edges 1 and 2, got %v", edges.Scores)
+	}
+
+	closeness, _ := algo.FindCloseness(gr)
+	if closeness.Ranking[0] != 2 {
+		t.Errorf("Expected node 2 to be the closest, got ranking %v", closeness.Ranking)
+	}
+
+	pageRank, err := algo.FindPageRank(gr, algo.WithPersonalization(map[graph.TKey]float64{4: 1}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	total := 0.0
+	for _, score := range pageRank.Scores {
+		total += score
+	}
+	if !pageRank.Converged || total < 0.999 || total > 1.001 || pageRank.Scores[4] <= pageRank.Scores[1] {
+		t.Errorf("Expected converged PageRank summing to 1 and favoring node 4, got %v", pageRank.Scores)
+	}
+
+	eigenvector, _ := algo.FindEigenvectorCentrality(gr)
+	if eigenvector.Ranking[0] != 2 {
+		t.Errorf("Expected node 2 to have the highest eigenvector centrality, got %v", eigenvector.Ranking)
+	}
+
+	// In directed star every arc leaves node 1: it is the hub, leaves are authorities
+	star := makeTestGraph(true, false, 4, [][3]int64{{1, 2, 1}, {1, 3, 1}, {1, 4, 1}})
+	hubs, authorities, err := algo.FindHITS(star)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if hubs.Ranking[0] != 1 || authorities.Scores[1] != 0 {
+		t.Errorf("Expected node 1 to be the only hub, got hubs %v and authorities %v", hubs.Scores, authorities.Scores)
+	}
+}
+
+func TestCommunityDetection(t *testing.T) {
+	// Two triangles joined by a single edge
+	gr := makeTestGraph(false, false, 6, [][3]int64{
+		{1, 2, 1}, {2, 3, 1}, {1, 3, 1}, {4, 5, 1}, {5, 6, 1}, {4, 6, 1}, {3, 4, 1},
+	})
+
+	louvain, err := algo.FindCommunitiesLouvain(gr, algo.WithCommunitySeed(7))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(louvain.Communities) != 2 || louvain.Membership[1] != louvain.Membership[3] || louvain.Membership[3] == louvain.Membership[4] {
+		t.Errorf("Expected triangles as communities, got %v", louvain.Communities)
+	}
+	if louvain.Modularity < 0.35 || louvain.Modularity > 0.36 {
+		t.Errorf("Expected modularity 5/14, got %f", louvain.Modularity)
+	}
+
+	propagation, err := algo.FindCommunitiesLabelPropagation(gr, algo.WithCommunitySeed(7))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	again, _ := algo.FindCommunitiesLabelPropagation(gr, algo.WithCommunitySeed(7))
+	if !slices.EqualFunc(propagation.Communities, again.Communities, slices.Equal) {
+		t.Errorf("Expected the same communities for the same seed, got %v and %v", propagation.Communities, again.Communities)
+	}
+
+	quotient, err := louvain.QuotientGraph(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(quotient.Nodes) != 2 || len(quotient.Edges) != 3 {
+		t.Errorf("Expected 2 nodes with 2 loops and 1 edge, got %d nodes and %d edges", len(quotient.Nodes), len(quotient.Edges))
+	}
+
+	// Unweighted detection counts edges in quotient graph, not their weights
+	heavy := gr.Copy()
+	for _, edge := range heavy.Edges {
+		edge.UpdateEdge(graph.WithEdgeWeight(5))
+	}
+	unweighted, _ := algo.FindCommunitiesLouvain(heavy, algo.WithCommunityWeighted(false))
+	quotient, err = unweighted.QuotientGraph(heavy)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, edge := range quotient.Edges {
+		expected := graph.TWeight(1)
+		if edge.Source == edge.Destination {
+			expected = 3
+		}
+		if edge.Weight != expected {
+			t.Errorf("Expected edge counts 3 inside and 1 between communities, got %d on %d-%d", edge.Weight, edge.Source, edge.Destination)
+		}
+	}
+
+	if _, err := algo.FindCommunitiesLouvain(makeTestGraph(true, false, 2, [][3]int64{{1, 2, 1}})); err == nil {
+		t.Error("Expected error for directed graph")
+	}
+}
+
+func TestTrianglesAndCores(t *testing.T) {
+	// K4 on 1..4 with tail 4-5-6
+	gr := makeTestGraph(false, false, 6, [][3]int64{
+		{1, 2, 1}, {1, 3, 1}, {1, 4, 1}, {2, 3, 1}, {2, 4, 1}, {3, 4, 1}, {4, 5, 1}, {5, 6, 1},
+	})
+
+	triangles, err := algo.CountTriangles(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if triangles.Triangles != 4 || triangles.NodeTriangles[4] != 3 {
+		t.Errorf("Expected 4 triangles, 3 through node 4, got %d and %v", triangles.Triangles, triangles.NodeTriangles)
+	}
+	if triangles.LocalClustering[1] != 1 || triangles.LocalClustering[4] != 0.5 || triangles.LocalClustering[6] != 0 {
+		t.Errorf("Unexpected local clustering %v", triangles.LocalClustering)
+	}
+	// Triples: 3 * 3 + 6 + 1 = 16, closed ones: 3 * 4 = 12
+	if triangles.Transitivity != 0.75 {
+		t.Errorf("Expected transitivity 0.75, got %f", triangles.Transitivity)
+	}
+
+	cores, err := algo.FindCoreDecomposition(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cores.Degeneracy != 3 || cores.CoreNumber[4] != 3 || cores.CoreNumber[5] != 1 || len(cores.Ordering) != 6 {
+		t.Errorf("Expected degeneracy 3 with tail in 1-core, got %v", cores.CoreNumber)
+	}
+
+	subgraph, err := algo.KCoreSubgraph(gr, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(subgraph.Nodes) != 4 || len(subgraph.Edges) != 6 || len(gr.Nodes) != 6 {
+		t.Errorf("Expected K4 as 2-core and untouched graph, got %d nodes and %d edges", len(subgraph.Nodes), len(subgraph.Edges))
+	}
+}
+
+func TestIsomorphismAndSubgraphMatching(t *testing.T) {
+	// 4-cycle with keys 1..4 and the same cycle relabeled 10, 20, 30, 40
+	cycle := makeTestGraph(false, false, 4, [][3]int64{{1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {4, 1, 1}})
+	relabeled := graph.MakeGraph()
+	for _, key := range []graph.TKey{10, 20, 30, 40} {
+		relabeled.AddNode(graph.MakeNode(key))
+	}
+	for i, pair := range [][2]graph.TKey{{10, 30}, {30, 20}, {20, 40}, {40, 10}} {
+		relabeled.AddEdge(graph.MakeEdge(graph.TKey(i+1), pair[0], pair[1]))
+	}
+
+	isomorphisms, err := algo.FindIsomorphisms(cycle, relabeled, algo.WithMaxMappings(0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Dihedral group of the square has 8 elements
+	if !isomorphisms.Found || len(isomorphisms.Mappings) != 8 {
+		t.Errorf("Expected 8 isomorphisms, got %d", len(isomorphisms.Mappings))
+	}
+	exact, _ := algo.FindIsomorphisms(cycle, relabeled, algo.WithMaxMappings(8))
+	if len(exact.Mappings) != 8 || exact.Truncated {
+		t.Errorf("Expected all 8 isomorphisms without truncation, got %d (truncated %v)", len(exact.Mappings), exact.Truncated)
+	}
+
+	weighted, _ := algo.FindIsomorphisms(cycle, relabeled, algo.WithEdgeMatch(algo.MatchEdgeWeights))
+	if weighted.Found {
+		t.Error("Expected no isomorphism with weights 1 against weights 0")
+	}
+
+	// Path of 3 nodes is in K4 only as non-induced subgraph
+	path := makeTestGraph(false, false, 3, [][3]int64{{1, 2, 1}, {2, 3, 1}})
+	complete := makeTestGraph(false, false, 4, [][3]int64{{1, 2, 1}, {1, 3, 1}, {1, 4, 1}, {2, 3, 1}, {2, 4, 1}, {3, 4, 1}})
+	induced, _ := algo.FindSubgraphMatches(path, complete)
+	if induced.Found {
+		t.Error("Expected no induced path in complete graph")
+	}
+	matches, err := algo.FindSubgraphMatches(path, complete, algo.WithInduced(false), algo.WithMaxMappings(5))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(matches.Mappings) != 5 || !matches.Truncated {
+		t.Errorf("Expected 5 mappings of 24 with limit reached, got %d", len(matches.Mappings))
+	}
+
+	if _, err := algo.FindIsomorphisms(cycle, makeTestGraph(true, false, 1, nil)); err == nil {
+		t.Error("Expected error for directed target and undirected pattern")
+	}
+}
+
+func TestPlanarity(t *testing.T) {
+	// K4 is planar, every node sees the other three
+	k4 := makeTestGraph(false, false, 4, [][3]int64{{1, 2, 1}, {1, 3, 1}, {1, 4, 1}, {2, 3, 1}, {2, 4, 1}, {3, 4, 1}})
+	result, err := algo.TestPlanarity(k4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsPlanar || len(result.Embedding[1]) != 3 {
+		t.Errorf("Expected K4 to be planar with full rotations, got %v", result.Embedding)
+	}
+
+	// K3,3 with one edge subdivided by node 7, plus a pendant edge outside the witness
+	k33 := makeTestGraph(false, false, 8, [][3]int64{
+		{1, 4, 1}, {1, 5, 1}, {1, 6, 1}, {2, 4, 1}, {2, 5, 1}, {2, 6, 1}, {3, 4, 1}, {3, 5, 1}, {3, 7, 1}, {7, 6, 1}, {8, 1, 1},
+	})
+	result, err = algo.TestPlanarity(k33)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.IsPlanar || result.KuratowskiType != "K3,3" || len(result.Kuratowski) != 10 {
+		t.Errorf("Expected K3,3 subdivision with 10 edges, got %s with %v", result.KuratowskiType, result.Kuratowski)
+	}
+	if !slices.Equal(result.BranchNodes, []graph.TKey{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Expected branch nodes 1..6, got %v", result.BranchNodes)
+	}
+
+	if _, err := algo.TestPlanarity(makeTestGraph(true, false, 1, nil)); err == nil {
+		t.Error("Expected error for directed graph")
+	}
+}
+
+func TestDominators(t *testing.T) {
+	// If-else diamond 1 → {2, 3} → 4 with loop 4 → 1 and unreachable node 5
+	gr := makeTestGraph(true, false, 5, [][3]int64{{1, 2, 1}, {1, 3, 1}, {2, 4, 1}, {3, 4, 1}, {4, 1, 1}, {5, 4, 1}})
+
+	dominators, err := algo.FindDominators(gr, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dominators.Immediate[4] != 1 || dominators.Immediate[2] != 1 || !slices.Equal(dominators.Unreachable, []graph.TKey{5}) {
+		t.Errorf("Expected node 1 to dominate all and node 5 unreachable, got %v", dominators.Immediate)
+	}
+	if !slices.Equal(dominators.Frontiers[2], []graph.TKey{4}) || !slices.Equal(dominators.Frontiers[4], []graph.TKey{1}) {
+		t.Errorf("Expected frontiers {4} for 2 and {1} for 4, got %v", dominators.Frontiers)
+	}
+	if len(dominators.Tree.Nodes) != 4 || len(dominators.Tree.Edges) != 3 || !dominators.Tree.Options.IsDirected {
+		t.Errorf("Expected directed dominator tree of 4 nodes, got %d nodes and %d edges", len(dominators.Tree.Nodes), len(dominators.Tree.Edges))
+	}
+
+	// Every path into the exit 4 goes through 4 only, node 5 reaches it directly
+	post, err := algo.FindPostDominators(gr, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if post.Immediate[2] != 4 || post.Immediate[1] != 4 || post.Immediate[5] != 4 || len(post.Unreachable) != 0 {
+		t.Errorf("Expected node 4 to post-dominate all, got %v", post.Immediate)
+	}
+
+	if _, err := algo.FindDominators(makeTestGraph(false, false, 1, nil), 1); err == nil {
+		t.Error("Expected error for undirected graph")
+	}
+}
+
+func TestKShortestPaths(t *testing.T) {
+	// Square 1-2-4 and 1-3-4 of equal weight, plus a heavier direct edge 1-4
+	gr := makeTestGraph(false, false, 4, [][3]int64{{1, 2, 1}, {2, 4, 2}, {1, 3, 2}, {3, 4, 1}, {1, 4, 5}})
+
+	dijkstra, err := algo.Dijkstra(gr, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dijkstra.Distances[4] != 3 || len(dijkstra.GetPath(4)) != 3 {
+		t.Errorf("Expected distance 3 to node 4, got %v", dijkstra.Distances)
+	}
+
+	yen, err := algo.FindKShortestPaths(gr, 1, 4, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	weights := []int64{}
+	for _, path := range yen.Paths {
+		weights = append(weights, path.Weight)
+	}
+	// Only three loopless paths exist: two of weight 3 and the direct edge
+	if !slices.Equal(weights, []int64{3, 3, 5}) {
+		t.Errorf("Expected weights [3 3 5], got %v", weights)
+	}
+
+	dag, err := algo.FindShortestPathDAG(gr, 1, 4)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if dag.Counts[4].Int64() != 2 || len(dag.DAG.Edges) != 4 || len(dag.Paths(0)) != 2 {
+		t.Errorf("Expected 2 shortest paths over 4 DAG edges, got %v with %d edges", dag.Counts[4], len(dag.DAG.Edges))
+	}
+
+	negative := makeTestGraph(true, false, 2, [][3]int64{{1, 2, -1}})
+	if _, err := algo.FindKShortestPaths(negative, 1, 2, 1); err == nil {
+		t.Error("Expected error for negative weight")
+	}
+}
+
+func TestContractionHierarchy(t *testing.T) {
+	// Directed grid 1→2→3 / 4→5→6 with cheap bottom row and a costly shortcut 1→3
+	gr := makeTestGraph(true, false, 6, [][3]int64{
+		{1, 2, 4}, {2, 3, 4}, {1, 4, 1}, {4, 5, 1}, {5, 6, 1}, {6, 3, 1}, {2, 5, 1}, {1, 3, 9},
+	})
+
+	bidirectional, err := algo.BidirectionalDijkstra(gr, 1, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bidirectional.Reachable || bidirectional.Path.Weight != 4 || !slices.Equal(bidirectional.Path.Vertices, []graph.TKey{1, 4, 5, 6, 3}) {
+		t.Errorf("Expected path 1-4-5-6-3 of weight 4, got %v", bidirectional.Path)
+	}
+
+	ch, err := algo.BuildContractionHierarchy(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for source := graph.TKey(1); source <= 6; source++ {
+		dijkstra, _ := algo.Dijkstra(gr, source)
+		for target := graph.TKey(1); target <= 6; target++ {
+			result, err := ch.Query(source, target)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			distance, reachable := dijkstra.Distances[target]
+			if result.Reachable != reachable || reachable && result.Path.Weight != distance {
+				t.Errorf("Query %d→%d: expected %d (reachable %v), got %v", source, target, distance, reachable, result.Path)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := ch.Save(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	saved := buf.String()
+	loaded, err := algo.LoadContractionHierarchy(strings.NewReader(saved), gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result, err := loaded.Query(1, 3); err != nil || result.Path.Weight != 4 || len(result.Path.Edges) != 4 {
+		t.Errorf("Expected loaded index to find path of 4 edges with weight 4, got %v, %v", result, err)
+	}
+
+	// Any mutation makes index outdated, and saved index no longer matches graph
+	gr.RemoveEdgeByKey(3)
+	if _, err := ch.Query(1, 3); err == nil {
+		t.Error("Expected error for outdated index")
+	}
+	if _, err := algo.LoadContractionHierarchy(strings.NewReader(saved), gr); err == nil {
+		t.Error("Expected error for index of another graph")
+	}
+	edge, _ := loaded.Graph().GetEdgeByKey(8)
+	edge.UpdateEdge(graph.WithEdgeWeight(1))
+	gr.Touch()
+	if loaded.Valid() {
+		t.Error("Expected Touch to invalidate loaded index")
+	}
+}
+
+func TestReachabilityAndTransitiveReduction(t *testing.T) {
+	// Dependency DAG 1→2→3→4 with redundant shortcuts 1→3 and 1→4, and a separate 5→6
+	dag := makeTestGraph(true, false, 6, [][3]int64{{1, 2, 0}, {2, 3, 0}, {3, 4, 0}, {1, 3, 0}, {1, 4, 0}, {5, 6, 0}})
+
+	index, err := algo.BuildReachabilityIndex(dag)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, query := range []struct {
+		from, to graph.TKey
+		expected bool
+	}{{1, 4, true}, {2, 2, true}, {4, 1, false}, {1, 6, false}} {
+		if reachable, err := index.CanReach(query.from, query.to); err != nil || reachable != query.expected {
+			t.Errorf("CanReach(%d, %d): expected %v, got %v (%v)", query.from, query.to, query.expected, reachable, err)
+		}
+	}
+	if _, err := index.CanReach(1, 7); err == nil {
+		t.Error("Expected error for unknown node")
+	}
+	if descendants, _ := index.Descendants(1); !slices.Equal(descendants, []graph.TKey{2, 3, 4}) {
+		t.Errorf("Expected descendants [2 3 4] of node 1, got %v", descendants)
+	}
+
+	closure, err := algo.TransitiveClosure(dag)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(closure.Graph.Edges) != 7 || closure.Added != 1 {
+		t.Errorf("Expected closure of 7 arcs with 1 new, got %d with %d new", len(closure.Graph.Edges), closure.Added)
+	}
+
+	reduction, err := algo.TransitiveReduction(dag)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(reduction.Removed, []graph.TKey{4, 5}) || len(reduction.Graph.Edges) != 4 {
+		t.Errorf("Expected edges 4 and 5 removed, got %v", reduction.Removed)
+	}
+
+	// Cycle 1→2→3→1 becomes one component reaching 4
+	cyclic := makeTestGraph(true, false, 4, [][3]int64{{1, 2, 0}, {2, 3, 0}, {3, 1, 0}, {3, 4, 0}})
+	index, err = algo.BuildReachabilityIndex(cyclic)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	back, _ := index.CanReach(2, 1)
+	forth, _ := index.CanReach(4, 3)
+	if index.Components() != 2 || !back || forth {
+		t.Errorf("Expected 2 components with cycle reaching node 4, got %d", index.Components())
+	}
+	if descendants, _ := index.Descendants(1); !slices.Equal(descendants, []graph.TKey{1, 2, 3, 4}) {
+		t.Errorf("Expected node 1 on cycle to reach itself, got %v", descendants)
+	}
+	if _, err := algo.TransitiveReduction(cyclic); err == nil {
+		t.Error("Expected error for graph with cycle")
+	}
+
+	cyclic.RemoveEdgeByKey(3)
+	if index.Valid() {
+		t.Error("Expected index to be outdated after removing edge")
+	}
+	if _, err := index.CanReach(1, 4); err == nil {
+		t.Error("Expected error for query on outdated index")
+	}
+	if _, err := index.Descendants(1); err == nil {
+		t.Error("Expected error for descendants on outdated index")
+	}
+}
+
+func TestShortestPathDAGZeroWeights(t *testing.T) {
+	// 1→3 directly and 1→2→3 through zero weight arc between nodes at equal distance
+	gr := makeTestGraph(true, false, 3, [][3]int64{{1, 2, 1}, {1, 3, 1}, {2, 3, 0}})
+
+	count, err := algo.CountShortestPaths(gr, 1, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count.Int64() != 2 {
+		t.Errorf("Expected 2 shortest paths, got %v", count)
+	}
+
+	dag, err := algo.FindShortestPathDAG(gr, 1, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := dag.DAG.Edges[3]; !ok || len(dag.DAG.Edges) != 3 {
+		t.Errorf("Expected all 3 arcs in DAG, got %d", len(dag.DAG.Edges))
+	}
+
+	// Zero weight cycle 2⇄3 keeps one of its arcs, DAG stays acyclic
+	cyclic := makeTestGraph(true, false, 3, [][3]int64{{1, 2, 1}, {1, 3, 1}, {2, 3, 0}, {3, 2, 0}})
+	dag, err = algo.FindShortestPathDAG(cyclic, 1, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(dag.DAG.Edges) != 3 || len(dag.Paths(0)) != 2 {
+		t.Errorf("Expected 3 arcs and 2 paths, got %d arcs and %d paths", len(dag.DAG.Edges), len(dag.Paths(0)))
+	}
+}
+
+func TestIndexesOutdatedByUpdateGraph(t *testing.T) {
+	gr := makeTestGraph(true, false, 3, [][3]int64{{1, 2, 1}, {2, 3, 1}})
+
+	hierarchy, err := algo.BuildContractionHierarchy(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reachability, err := algo.BuildReachabilityIndex(gr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Replacing edges through options keeps graph options, but still changes graph
+	gr.UpdateGraph(graph.WithGraphEdges(map[graph.TKey]*graph.Edge{1: graph.MakeEdge(1, 3, 1)}))
+	if _, err := hierarchy.Query(1, 3); err == nil {
+		t.Error("Expected contraction hierarchy to be outdated after UpdateGraph")
+	}
+	if _, err := reachability.CanReach(1, 3); err == nil {
+		t.Error("Expected reachability index to be outdated after UpdateGraph")
+	}
+}